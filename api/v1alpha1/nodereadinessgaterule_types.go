@@ -19,31 +19,143 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // NodeReadinessGateRuleSpec defines the desired state of NodeReadinessGateRule
 type NodeReadinessGateRuleSpec struct {
 	// Replace single ConditionType with multiple conditions
-	Conditions []ConditionRequirement `json:"conditions"`
+	Conditions []ConditionRequirement `json:"conditions,omitempty"`
+
+	// ConditionGroups combine conditions with All/Any/AtLeast logic. Every
+	// group must be satisfied, in addition to every entry in Conditions.
+	ConditionGroups []ConditionGroup `json:"conditionGroups,omitempty"`
+
+	// Expressions are CEL expressions evaluated against the Node object,
+	// all of which must return true for the rule to be satisfied.
+	Expressions []ExpressionRequirement `json:"expressions,omitempty"`
 
 	// Add enforcement mode
 	EnforcementMode EnforcementMode `json:"enforcementMode"`
 
-	// Simplify taint specification (remove TaintKey, TaintEffect separation)
-	Taint TaintSpec `json:"taint"`
+	// Taint is the single taint managed by the rule.
+	// Deprecated: use Taints, which also accepts a single entry.
+	Taint *TaintSpec `json:"taint,omitempty"`
+
+	// Taints are managed together: they are all added when the rule is not
+	// satisfied and all removed once it is, each time in a single node patch.
+	Taints []TaintSpec `json:"taints,omitempty"`
+
+	// AdoptExisting lets the rule manage matching taints it did not add,
+	// such as taints set at node registration. Otherwise only taints the
+	// controller added for this rule are ever removed.
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// Actions gate the node in other ways, in place of or alongside Taints,
+	// for consumers that do not look at taints.
+	Actions *GateActions `json:"actions,omitempty"`
 
 	// Keep existing fields
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
-	GracePeriod  *metav1.Duration      `json:"gracePeriod,omitempty"`
+
+	// GracePeriod is how long conditions must stay unsatisfied before the
+	// taint is added, so that brief condition flaps do not taint the node.
+	// It only applies to nodes that satisfied the rule before; new nodes
+	// are tainted right away.
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// StableFor is how long all conditions must have been satisfied, judged by
+	// their lastTransitionTime, before the taint is removed.
+	StableFor *metav1.Duration `json:"stableFor,omitempty"`
+
+	// RearmOn re-applies a bootstrap-only rule on nodes that already
+	// completed bootstrap when the node changes in one of the listed ways.
+	RearmOn *RearmPolicy `json:"rearmOn,omitempty"`
 
 	// Add dry run support
 	DryRun bool `json:"dryRun,omitempty"`
+
+	// MaxUnavailable caps how many matched nodes the rule may gate at once,
+	// as an absolute number or a percentage of matched nodes (rounded up).
+	// Once reached, further taints are held back and the rule reports
+	// Degraded; taint removals continue.
+	// +kubebuilder:validation:XIntOrString
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Rollout spreads the taints of a new or changed rule across the fleet
+	// in batches, instead of tainting every unsatisfied node at once.
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+
+	// Canary enforces the rule only on a subset of its nodes; the other
+	// nodes are evaluated as in dry run until the canary is promoted.
+	Canary *CanaryStrategy `json:"canary,omitempty"`
 }
 
 // New types to add
 type ConditionRequirement struct {
 	Type           string                 `json:"type"`
 	RequiredStatus corev1.ConditionStatus `json:"requiredStatus"`
+
+	// MissingPolicy decides how the condition is treated when the node does
+	// not report it at all. Defaults to TreatAsUnsatisfied.
+	MissingPolicy MissingPolicy `json:"missingPolicy,omitempty"`
+
+	// MissingTimeout is used with TreatAsSatisfiedAfter: a missing condition
+	// is treated as satisfied once the node is older than this duration.
+	MissingTimeout *metav1.Duration `json:"missingTimeout,omitempty"`
+
+	// MaxAge treats the condition as unsatisfied when its lastHeartbeatTime
+	// (or lastTransitionTime, if no heartbeat is reported) is older than this,
+	// which catches agents that died after reporting True.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+type MissingPolicy string
+
+const (
+	MissingPolicyTreatAsUnsatisfied    MissingPolicy = "TreatAsUnsatisfied"
+	MissingPolicyTreatAsSatisfied      MissingPolicy = "TreatAsSatisfied"
+	MissingPolicyTreatAsSatisfiedAfter MissingPolicy = "TreatAsSatisfiedAfter"
+)
+
+// ConditionGroupOperator defines how the members of a condition group are combined
+type ConditionGroupOperator string
+
+const (
+	ConditionGroupOperatorAll     ConditionGroupOperator = "All"
+	ConditionGroupOperatorAny     ConditionGroupOperator = "Any"
+	ConditionGroupOperatorAtLeast ConditionGroupOperator = "AtLeast"
+)
+
+// ConditionGroup is satisfied when its operator holds over its conditions and
+// nested groups, e.g. Any of CalicoReady and CiliumReady.
+type ConditionGroup struct {
+	Name     string                 `json:"name"`
+	Operator ConditionGroupOperator `json:"operator"`
+
+	// MinSatisfied is the number of members that must be satisfied when
+	// Operator is AtLeast
+	MinSatisfied int `json:"minSatisfied,omitempty"`
+
+	Conditions []ConditionRequirement `json:"conditions,omitempty"`
+	Groups     []ConditionSubGroup    `json:"groups,omitempty"`
+}
+
+// ConditionSubGroup is a group nested inside a ConditionGroup. CRD schemas
+// cannot be recursive, so nesting stops at this level.
+type ConditionSubGroup struct {
+	Name         string                 `json:"name"`
+	Operator     ConditionGroupOperator `json:"operator"`
+	MinSatisfied int                    `json:"minSatisfied,omitempty"`
+	Conditions   []ConditionRequirement `json:"conditions"`
+}
+
+// ExpressionRequirement is a CEL expression over the `node` variable, e.g.
+// node.status.allocatable['nvidia.com/gpu'] > 0. Resource quantities in
+// status.capacity and status.allocatable are exposed as integers.
+type ExpressionRequirement struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
 }
 
 type TaintSpec struct {
@@ -52,6 +164,88 @@ type TaintSpec struct {
 	Value  string             `json:"value,omitempty"`
 }
 
+// RearmPolicy lists the node changes that re-arm a bootstrap-only rule
+type RearmPolicy struct {
+	// BootID re-arms the rule when node.status.nodeInfo.bootID changes,
+	// i.e. after the node reboots.
+	BootID bool `json:"bootID,omitempty"`
+
+	// KubeletVersion re-arms the rule when the kubelet version changes.
+	KubeletVersion bool `json:"kubeletVersion,omitempty"`
+
+	// Labels re-arms the rule when the value of any of these node labels changes.
+	Labels []string `json:"labels,omitempty"`
+}
+
+// RolloutStrategy releases the nodes of a rule for enforcement in batches.
+// Nodes are taken in order of the OrderBy label value, then by name; each
+// batch takes nodes until BatchSize of them would be tainted.
+type RolloutStrategy struct {
+	// BatchSize is how many nodes are tainted per batch, as an absolute
+	// number or a percentage of matched nodes (rounded up).
+	// +kubebuilder:validation:XIntOrString
+	BatchSize intstr.IntOrString `json:"batchSize"`
+
+	// Pause is how long to wait after a batch before releasing the next one.
+	Pause metav1.Duration `json:"pause"`
+
+	// OrderBy is a node label, such as topology.kubernetes.io/zone, whose
+	// value orders the nodes, so that enforcement reaches one zone after
+	// another. Nodes without the label come first.
+	OrderBy string `json:"orderBy,omitempty"`
+}
+
+// CanaryStrategy picks the nodes a rule is enforced on before it is enforced
+// everywhere. Exactly one of Percentage and Selector must be set.
+type CanaryStrategy struct {
+	// Percentage of matched nodes to enforce the rule on. Nodes are picked
+	// by a stable hash of the rule and node names.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// Selector picks the canary nodes by label, among the matched nodes.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// PromoteAfter promotes the rule to full enforcement once every canary
+	// node has satisfied it for this long. Without it, promotion is manual:
+	// remove the canary from the spec.
+	PromoteAfter *metav1.Duration `json:"promoteAfter,omitempty"`
+}
+
+// GateActions are applied to a node while the rule is not satisfied and
+// reverted once it is, together with the rule's taints
+type GateActions struct {
+	// Cordon marks the node unschedulable while the rule is not satisfied.
+	Cordon bool `json:"cordon,omitempty"`
+
+	// Label is set on the node while the rule is not satisfied.
+	Label *LabelAction `json:"label,omitempty"`
+
+	// Condition is written to the node status, False while the rule is not
+	// satisfied and True once it is.
+	Condition *ConditionAction `json:"condition,omitempty"`
+}
+
+type LabelAction struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type ConditionAction struct {
+	// Type of the node condition. Defaults to ReadinessGates/<rule name>.
+	Type string `json:"type,omitempty"`
+}
+
+// GetTaints returns every taint managed by the rule: the deprecated Taint,
+// if set, followed by Taints
+func (s *NodeReadinessGateRuleSpec) GetTaints() []TaintSpec {
+	if s.Taint == nil {
+		return s.Taints
+	}
+	return append([]TaintSpec{*s.Taint}, s.Taints...)
+}
+
 type EnforcementMode string
 
 const (
@@ -60,25 +254,93 @@ const (
 )
 
 // NodeReadinessGateRuleStatus defines the observed state of NodeReadinessGateRule.
+// Per-node evaluation results are reported in NodeReadinessStatus objects so
+// that the size of the rule does not grow with the size of the cluster.
 type NodeReadinessGateRuleStatus struct {
 	// Keep existing
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
-	AppliedNodes       []string           `json:"appliedNodes,omitempty"`
 
-	// Add new status tracking
-	NodeEvaluations []NodeEvaluation `json:"nodeEvaluations,omitempty"`
-	FailedNodes     []NodeFailure    `json:"failedNodes,omitempty"`
+	// MatchedNodes is the number of nodes the rule applies to
+	MatchedNodes int32 `json:"matchedNodes"`
+
+	// ReadyNodes is the number of matched nodes that satisfy the rule and are not gated
+	ReadyNodes int32 `json:"readyNodes"`
+
+	// TaintedNodes is the number of matched nodes the rule currently gates
+	TaintedNodes int32 `json:"taintedNodes"`
+
+	// FailedNodes is the number of matched nodes the rule failed to evaluate or enforce
+	FailedNodes int32 `json:"failedNodes"`
+
+	// FailingNodes is a sample of the nodes the rule most recently failed to
+	// evaluate or enforce
+	// +kubebuilder:validation:MaxItems=10
+	FailingNodes []NodeFailure `json:"failingNodes,omitempty"`
+
+	// AppliedTaints are the taints the rule last enforced. Nodes are migrated
+	// from them when the taint spec changes, also across controller restarts.
+	AppliedTaints []TaintSpec `json:"appliedTaints,omitempty"`
 
 	// Add dry run results
 	DryRunResults *DryRunResults `json:"dryRunResults,omitempty"`
+
+	// Rollout tracks the progressive rollout of the rule's taints
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// Canary tracks canary enforcement and its promotion
+	Canary *CanaryStatus `json:"canary,omitempty"`
+}
+
+// CanaryStatus records the state of canary enforcement of a rule
+type CanaryStatus struct {
+	// ObservedGeneration is the latest rule generation the canary was
+	// evaluated for
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// EvaluationHash identifies the conditions, expressions, taints and
+	// actions the canary enforces; a change to them starts a new canary
+	EvaluationHash string `json:"evaluationHash,omitempty"`
+
+	// CanaryNodes is the number of matched nodes the rule is enforced on
+	CanaryNodes int32 `json:"canaryNodes"`
+
+	// CompliantSince is when every canary node last started satisfying the rule
+	CompliantSince *metav1.Time `json:"compliantSince,omitempty"`
+
+	// Promoted is true once the rule is enforced on all matched nodes
+	Promoted bool `json:"promoted,omitempty"`
+
+	// PromotionTime is when the canary was promoted
+	PromotionTime *metav1.Time `json:"promotionTime,omitempty"`
 }
 
-type NodeEvaluation struct {
-	NodeName         string                      `json:"nodeName"`
-	ConditionResults []ConditionEvaluationResult `json:"conditionResults"`
-	TaintStatus      string                      `json:"taintStatus"` // "Present", "Absent", "Unknown"
-	LastEvaluated    metav1.Time                 `json:"lastEvaluated"`
+// RolloutStatus records how far enforcement of a rule has reached. Nodes up
+// to and including the last released node are enforced.
+type RolloutStatus struct {
+	// ObservedGeneration is the latest rule generation the rollout was
+	// checked against
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// EvaluationHash identifies the conditions, expressions, taints and
+	// actions the rollout enforces; a change to them starts a new rollout
+	EvaluationHash string `json:"evaluationHash,omitempty"`
+
+	// LastNodeName and LastOrderValue identify the last released node
+	LastNodeName   string `json:"lastNodeName,omitempty"`
+	LastOrderValue string `json:"lastOrderValue,omitempty"`
+
+	// ReleasedNodes is the number of matched nodes enforcement has reached
+	ReleasedNodes int32 `json:"releasedNodes"`
+
+	// Batches is the number of batches released so far
+	Batches int32 `json:"batches"`
+
+	// LastBatchTime is when the latest batch was released
+	LastBatchTime *metav1.Time `json:"lastBatchTime,omitempty"`
+
+	// Complete is true once every matched node is enforced
+	Complete bool `json:"complete,omitempty"`
 }
 
 type ConditionEvaluationResult struct {
@@ -86,7 +348,34 @@ type ConditionEvaluationResult struct {
 	CurrentStatus  corev1.ConditionStatus `json:"currentStatus"`
 	RequiredStatus corev1.ConditionStatus `json:"requiredStatus"`
 	Satisfied      bool                   `json:"satisfied"`
-	Missing        bool                   `json:"missing"`
+
+	// Missing is true when the node does not report the condition at all,
+	// as opposed to reporting it with status Unknown
+	Missing bool `json:"missing"`
+
+	// Stale is true when the condition is older than the requirement's maxAge
+	Stale bool `json:"stale,omitempty"`
+
+	// Group is the condition group this condition belongs to, empty for
+	// top-level conditions
+	Group string `json:"group,omitempty"`
+}
+
+type ConditionGroupResult struct {
+	// Name is the group name; nested groups are reported as "<parent>/<child>"
+	Name           string                 `json:"name"`
+	Operator       ConditionGroupOperator `json:"operator"`
+	SatisfiedCount int                    `json:"satisfiedCount"`
+	Total          int                    `json:"total"`
+	Satisfied      bool                   `json:"satisfied"`
+}
+
+type ExpressionEvaluationResult struct {
+	Name      string `json:"name"`
+	Satisfied bool   `json:"satisfied"`
+
+	// Error is set when the expression failed to compile or evaluate
+	Error string `json:"error,omitempty"`
 }
 
 type NodeFailure struct {
@@ -97,16 +386,70 @@ type NodeFailure struct {
 }
 
 type DryRunResults struct {
-	AffectedNodes   int    `json:"affectedNodes"`
-	TaintsToAdd     int    `json:"taintsToAdd"`
-	TaintsToRemove  int    `json:"taintsToRemove"`
-	RiskyOperations int    `json:"riskyOperations"`
-	Summary         string `json:"summary"`
+	AffectedNodes   int `json:"affectedNodes"`
+	TaintsToAdd     int `json:"taintsToAdd"`
+	TaintsToRemove  int `json:"taintsToRemove"`
+	RiskyOperations int `json:"riskyOperations"`
+
+	// Nodes that do not report a required condition at all
+	NodesWithMissingConditions int `json:"nodesWithMissingConditions,omitempty"`
+	// Nodes that report a required condition with status Unknown
+	NodesWithUnknownConditions int `json:"nodesWithUnknownConditions,omitempty"`
+
+	Summary string `json:"summary"`
+
+	// Nodes is a sample of the nodes whose taints would change, in node name
+	// order. The full plan is published in the ConfigMap named by PlanConfigMap.
+	// +kubebuilder:validation:MaxItems=20
+	Nodes []NodeDryRunPlan `json:"nodes,omitempty"`
+
+	// PlanConfigMap is the namespace/name of the ConfigMap holding the full
+	// plan, empty when the controller does not publish plans
+	PlanConfigMap string `json:"planConfigMap,omitempty"`
 }
 
+// DryRunAction is the change a rule in dry run would make to a node
+type DryRunAction string
+
+const (
+	// DryRunActionTaint means the node does not satisfy the rule and would be tainted
+	DryRunActionTaint DryRunAction = "Taint"
+	// DryRunActionUntaint means the node satisfies the rule and would be untainted
+	DryRunActionUntaint DryRunAction = "Untaint"
+)
+
+// NodeDryRunPlan is the change a rule in dry run would make to one node
+type NodeDryRunPlan struct {
+	NodeName string       `json:"nodeName"`
+	Action   DryRunAction `json:"action"`
+
+	// FailingConditions lists the conditions, condition groups and
+	// expressions the node does not satisfy
+	FailingConditions []string `json:"failingConditions,omitempty"`
+}
+
+// Condition types reported in NodeReadinessGateRule status
+const (
+	// RuleConditionReady is True when every matched node satisfies the rule
+	RuleConditionReady = "Ready"
+	// RuleConditionProgressing is True while nodes wait out a grace period or stableFor window
+	RuleConditionProgressing = "Progressing"
+	// RuleConditionDegraded is True when the rule cannot be evaluated or enforced on some nodes
+	RuleConditionDegraded = "Degraded"
+	// RuleConditionDryRun is True when the rule only reports what it would do
+	RuleConditionDryRun = "DryRun"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:resource:scope=Cluster,shortName=nrgr
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.enforcementMode`
+// +kubebuilder:printcolumn:name="Dry Run",type=boolean,JSONPath=`.spec.dryRun`
+// +kubebuilder:printcolumn:name="Matched",type=integer,JSONPath=`.status.matchedNodes`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyNodes`
+// +kubebuilder:printcolumn:name="Tainted",type=integer,JSONPath=`.status.taintedNodes`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // NodeReadinessGateRule is the Schema for the nodereadinessgaterules API
 type NodeReadinessGateRule struct {
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeReadinessStatusStatus defines the observed state of NodeReadinessStatus.
+type NodeReadinessStatusStatus struct {
+	// RuleEvaluations holds the latest evaluation of each rule that applies to the node
+	RuleEvaluations []RuleEvaluation `json:"ruleEvaluations,omitempty"`
+}
+
+type RuleEvaluation struct {
+	RuleName         string                      `json:"ruleName"`
+	ConditionResults []ConditionEvaluationResult `json:"conditionResults"`
+	TaintStatus      string                      `json:"taintStatus"` // "Present", "Absent", "Pending", "HeldBack", "Unknown"
+
+	// LastEvaluated is when the outcome of the evaluation last changed
+	LastEvaluated metav1.Time `json:"lastEvaluated"`
+
+	// GroupResults reports the outcome of each condition group
+	GroupResults []ConditionGroupResult `json:"groupResults,omitempty"`
+
+	// ExpressionResults reports the outcome of each CEL expression
+	ExpressionResults []ExpressionEvaluationResult `json:"expressionResults,omitempty"`
+
+	// PendingTaintUntil is when the taint will be added if conditions are
+	// still unsatisfied once the rule's grace period expires.
+	PendingTaintUntil *metav1.Time `json:"pendingTaintUntil,omitempty"`
+
+	// PendingRemovalUntil is when the taint will be removed if conditions
+	// stay satisfied for the rule's stableFor window.
+	PendingRemovalUntil *metav1.Time `json:"pendingRemovalUntil,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// NodeReadinessStatus reports how the readiness gate rules evaluate on a
+// single node. It has the name of the node and is owned by it.
+type NodeReadinessStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// status defines the observed state of NodeReadinessStatus
+	// +optional
+	Status NodeReadinessStatusStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeReadinessStatusList contains a list of NodeReadinessStatus
+type NodeReadinessStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeReadinessStatus `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeReadinessStatus{}, &NodeReadinessStatusList{})
+}
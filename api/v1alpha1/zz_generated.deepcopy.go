@@ -23,8 +23,77 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.CompliantSince != nil {
+		in, out := &in.CompliantSince, &out.CompliantSince
+		*out = (*in).DeepCopy()
+	}
+	if in.PromotionTime != nil {
+		in, out := &in.PromotionTime, &out.PromotionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStrategy) DeepCopyInto(out *CanaryStrategy) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromoteAfter != nil {
+		in, out := &in.PromoteAfter, &out.PromoteAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStrategy.
+func (in *CanaryStrategy) DeepCopy() *CanaryStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionAction) DeepCopyInto(out *ConditionAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionAction.
+func (in *ConditionAction) DeepCopy() *ConditionAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionEvaluationResult) DeepCopyInto(out *ConditionEvaluationResult) {
 	*out = *in
@@ -40,9 +109,63 @@ func (in *ConditionEvaluationResult) DeepCopy() *ConditionEvaluationResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionGroup) DeepCopyInto(out *ConditionGroup) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ConditionRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]ConditionSubGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionGroup.
+func (in *ConditionGroup) DeepCopy() *ConditionGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionGroupResult) DeepCopyInto(out *ConditionGroupResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionGroupResult.
+func (in *ConditionGroupResult) DeepCopy() *ConditionGroupResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionGroupResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionRequirement) DeepCopyInto(out *ConditionRequirement) {
 	*out = *in
+	if in.MissingTimeout != nil {
+		in, out := &in.MissingTimeout, &out.MissingTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionRequirement.
@@ -55,9 +178,38 @@ func (in *ConditionRequirement) DeepCopy() *ConditionRequirement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionSubGroup) DeepCopyInto(out *ConditionSubGroup) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ConditionRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionSubGroup.
+func (in *ConditionSubGroup) DeepCopy() *ConditionSubGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionSubGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DryRunResults) DeepCopyInto(out *DryRunResults) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeDryRunPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunResults.
@@ -71,22 +223,91 @@ func (in *DryRunResults) DeepCopy() *DryRunResults {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeEvaluation) DeepCopyInto(out *NodeEvaluation) {
+func (in *ExpressionEvaluationResult) DeepCopyInto(out *ExpressionEvaluationResult) {
 	*out = *in
-	if in.ConditionResults != nil {
-		in, out := &in.ConditionResults, &out.ConditionResults
-		*out = make([]ConditionEvaluationResult, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionEvaluationResult.
+func (in *ExpressionEvaluationResult) DeepCopy() *ExpressionEvaluationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionEvaluationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressionRequirement) DeepCopyInto(out *ExpressionRequirement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionRequirement.
+func (in *ExpressionRequirement) DeepCopy() *ExpressionRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateActions) DeepCopyInto(out *GateActions) {
+	*out = *in
+	if in.Label != nil {
+		in, out := &in.Label, &out.Label
+		*out = new(LabelAction)
+		**out = **in
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(ConditionAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateActions.
+func (in *GateActions) DeepCopy() *GateActions {
+	if in == nil {
+		return nil
+	}
+	out := new(GateActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelAction) DeepCopyInto(out *LabelAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelAction.
+func (in *LabelAction) DeepCopy() *LabelAction {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDryRunPlan) DeepCopyInto(out *NodeDryRunPlan) {
+	*out = *in
+	if in.FailingConditions != nil {
+		in, out := &in.FailingConditions, &out.FailingConditions
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	in.LastEvaluated.DeepCopyInto(&out.LastEvaluated)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeEvaluation.
-func (in *NodeEvaluation) DeepCopy() *NodeEvaluation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDryRunPlan.
+func (in *NodeDryRunPlan) DeepCopy() *NodeDryRunPlan {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeEvaluation)
+	out := new(NodeDryRunPlan)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -172,9 +393,37 @@ func (in *NodeReadinessGateRuleSpec) DeepCopyInto(out *NodeReadinessGateRuleSpec
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ConditionRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionGroups != nil {
+		in, out := &in.ConditionGroups, &out.ConditionGroups
+		*out = make([]ConditionGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]ExpressionRequirement, len(*in))
 		copy(*out, *in)
 	}
-	out.Taint = in.Taint
+	if in.Taint != nil {
+		in, out := &in.Taint, &out.Taint
+		*out = new(TaintSpec)
+		**out = **in
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]TaintSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = new(GateActions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = new(v1.LabelSelector)
@@ -185,6 +434,31 @@ func (in *NodeReadinessGateRuleSpec) DeepCopyInto(out *NodeReadinessGateRuleSpec
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.StableFor != nil {
+		in, out := &in.StableFor, &out.StableFor
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RearmOn != nil {
+		in, out := &in.RearmOn, &out.RearmOn
+		*out = new(RearmPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStrategy)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeReadinessGateRuleSpec.
@@ -207,29 +481,32 @@ func (in *NodeReadinessGateRuleStatus) DeepCopyInto(out *NodeReadinessGateRuleSt
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.AppliedNodes != nil {
-		in, out := &in.AppliedNodes, &out.AppliedNodes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NodeEvaluations != nil {
-		in, out := &in.NodeEvaluations, &out.NodeEvaluations
-		*out = make([]NodeEvaluation, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.FailedNodes != nil {
-		in, out := &in.FailedNodes, &out.FailedNodes
+	if in.FailingNodes != nil {
+		in, out := &in.FailingNodes, &out.FailingNodes
 		*out = make([]NodeFailure, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AppliedTaints != nil {
+		in, out := &in.AppliedTaints, &out.AppliedTaints
+		*out = make([]TaintSpec, len(*in))
+		copy(*out, *in)
+	}
 	if in.DryRunResults != nil {
 		in, out := &in.DryRunResults, &out.DryRunResults
 		*out = new(DryRunResults)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -243,6 +520,181 @@ func (in *NodeReadinessGateRuleStatus) DeepCopy() *NodeReadinessGateRuleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeReadinessStatus) DeepCopyInto(out *NodeReadinessStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeReadinessStatus.
+func (in *NodeReadinessStatus) DeepCopy() *NodeReadinessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeReadinessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeReadinessStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeReadinessStatusList) DeepCopyInto(out *NodeReadinessStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeReadinessStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeReadinessStatusList.
+func (in *NodeReadinessStatusList) DeepCopy() *NodeReadinessStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeReadinessStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeReadinessStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeReadinessStatusStatus) DeepCopyInto(out *NodeReadinessStatusStatus) {
+	*out = *in
+	if in.RuleEvaluations != nil {
+		in, out := &in.RuleEvaluations, &out.RuleEvaluations
+		*out = make([]RuleEvaluation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeReadinessStatusStatus.
+func (in *NodeReadinessStatusStatus) DeepCopy() *NodeReadinessStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeReadinessStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RearmPolicy) DeepCopyInto(out *RearmPolicy) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RearmPolicy.
+func (in *RearmPolicy) DeepCopy() *RearmPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RearmPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.LastBatchTime != nil {
+		in, out := &in.LastBatchTime, &out.LastBatchTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	out.BatchSize = in.BatchSize
+	out.Pause = in.Pause
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleEvaluation) DeepCopyInto(out *RuleEvaluation) {
+	*out = *in
+	if in.ConditionResults != nil {
+		in, out := &in.ConditionResults, &out.ConditionResults
+		*out = make([]ConditionEvaluationResult, len(*in))
+		copy(*out, *in)
+	}
+	in.LastEvaluated.DeepCopyInto(&out.LastEvaluated)
+	if in.GroupResults != nil {
+		in, out := &in.GroupResults, &out.GroupResults
+		*out = make([]ConditionGroupResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpressionResults != nil {
+		in, out := &in.ExpressionResults, &out.ExpressionResults
+		*out = make([]ExpressionEvaluationResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingTaintUntil != nil {
+		in, out := &in.PendingTaintUntil, &out.PendingTaintUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingRemovalUntil != nil {
+		in, out := &in.PendingRemovalUntil, &out.PendingRemovalUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleEvaluation.
+func (in *RuleEvaluation) DeepCopy() *RuleEvaluation {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleEvaluation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaintSpec) DeepCopyInto(out *TaintSpec) {
 	*out = *in
@@ -20,17 +20,23 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	"go.uber.org/zap/zapcore"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -60,6 +66,16 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var enableWebhook bool
+	var statusFlushInterval time.Duration
+	var statusFlushMaxPending int
+	var ruleConcurrency int
+	var nodeConcurrency int
+	var nodeWorkers int
+	var globalDryRun bool
+	var dryRunConfigMap string
+	var dryRunConfigMapNamespace string
+	var dryRunPlanNamespace string
+	var maxUnavailable string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -68,6 +84,31 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&enableWebhook, "enable-webhook", false,
 		"Enable validation webhook. Requires TLS certificates to be configured.")
+	flag.DurationVar(&statusFlushInterval, "status-flush-interval", 2*time.Second,
+		"How long rule status changes from node events are coalesced before they are written.")
+	flag.IntVar(&statusFlushMaxPending, "status-flush-max-pending", 100,
+		"Number of queued rule status changes that triggers a write before the flush interval expires.")
+
+	flag.IntVar(&ruleConcurrency, "rule-concurrency", 1,
+		"Number of rules reconciled in parallel.")
+	flag.IntVar(&nodeConcurrency, "node-concurrency", 1,
+		"Number of nodes reconciled in parallel.")
+	flag.IntVar(&nodeWorkers, "node-workers", 10,
+		"Number of nodes evaluated in parallel when a rule is applied to all nodes.")
+
+	flag.BoolVar(&globalDryRun, "global-dry-run", false,
+		"Freeze all taint changes: rules only report the changes they would make.")
+	flag.StringVar(&dryRunConfigMap, "dry-run-configmap", "nrg-controller-config",
+		"Name of the ConfigMap whose \"globalDryRun\" key toggles the global dry run at runtime. "+
+			"Leave empty to disable the runtime toggle.")
+	flag.StringVar(&dryRunConfigMapNamespace, "dry-run-configmap-namespace", os.Getenv("POD_NAMESPACE"),
+		"Namespace of the global dry run ConfigMap. Defaults to the POD_NAMESPACE environment variable.")
+	flag.StringVar(&dryRunPlanNamespace, "dry-run-plan-namespace", os.Getenv("POD_NAMESPACE"),
+		"Namespace of the \"nrg-dry-run-<rule>\" ConfigMaps holding the full per-node dry run plan of each rule. "+
+			"Defaults to the POD_NAMESPACE environment variable; leave empty to only report a sample in rule status.")
+	flag.StringVar(&maxUnavailable, "max-unavailable", "",
+		"Maximum number of nodes all rules together may gate, as a number or a percentage of the nodes "+
+			"matched by any rule (e.g. 10%). Further taints are held back; removals continue. Empty means no limit.")
 
 	opts := zap.Options{
 		Development:     true,
@@ -83,8 +124,25 @@ func main() {
 		BindAddress: metricsAddr,
 	}
 
+	// The runtime dry run toggle needs a namespace to look in
+	if dryRunConfigMapNamespace == "" {
+		dryRunConfigMap = ""
+	}
+
+	// Only the dry run ConfigMap is watched, not every ConfigMap in the cluster
+	cacheOptions := cache.Options{}
+	if dryRunConfigMap != "" {
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{dryRunConfigMapNamespace: {}},
+				Field:      fields.OneTermEqualSelector("metadata.name", dryRunConfigMap),
+			},
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -104,18 +162,38 @@ func main() {
 
 	// Create the main ReadinessGateController
 	readinessController := controller.NewReadinessGateController(mgr, clientset)
+	readinessController.SetStatusBatching(statusFlushInterval, statusFlushMaxPending)
+	readinessController.SetNodeWorkers(nodeWorkers)
+	readinessController.SetGlobalDryRun(globalDryRun)
+	readinessController.SetDryRunPlanNamespace(dryRunPlanNamespace)
+	if globalDryRun {
+		setupLog.Info("global dry run enabled, taint changes are frozen")
+	}
+	if maxUnavailable != "" {
+		limit := intstr.Parse(maxUnavailable)
+		if value, err := intstr.GetScaledValueFromIntOrPercent(&limit, 100, true); err != nil || value < 0 ||
+			(limit.Type == intstr.String && value > 100) {
+			setupLog.Error(err, "invalid --max-unavailable, expected a non-negative number or percentage",
+				"value", maxUnavailable)
+			os.Exit(1)
+		}
+		readinessController.SetGlobalMaxUnavailable(&limit)
+		setupLog.Info("global maxUnavailable set", "maxUnavailable", limit.String())
+	}
 
 	// Create reconcilers linked to the main controller
 	ruleReconciler := &controller.RuleReconciler{
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		Controller: readinessController,
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Controller:              readinessController,
+		MaxConcurrentReconciles: ruleConcurrency,
 	}
 
 	nodeReconciler := &controller.NodeReconciler{
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		Controller: readinessController,
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Controller:              readinessController,
+		MaxConcurrentReconciles: nodeConcurrency,
 	}
 
 	// Setup controllers with manager
@@ -129,6 +207,21 @@ func main() {
 		os.Exit(1)
 	}
 
+	if dryRunConfigMap != "" {
+		dryRunConfigReconciler := &controller.DryRunConfigReconciler{
+			Client:     mgr.GetClient(),
+			Controller: readinessController,
+			Namespace:  dryRunConfigMapNamespace,
+			Name:       dryRunConfigMap,
+		}
+		if err := dryRunConfigReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DryRunConfig")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("global dry run ConfigMap toggle disabled")
+	}
+
 	// Setup webhook (conditional based on flag)
 	if enableWebhook {
 		nodeReadinessWebhook := webhook.NewNodeReadinessGateRuleWebhook(mgr.GetClient())
@@ -0,0 +1,376 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+const (
+	// bootstrapCompletedAnnotation records, per rule UID, the bootstrap-only
+	// rules a node has completed
+	bootstrapCompletedAnnotation = "readiness.k8s.io/bootstrap-completed"
+
+	// legacyBootstrapAnnotationPrefix is the per-rule annotation used before
+	// completions were keyed by rule UID. It is migrated on first sight.
+	legacyBootstrapAnnotationPrefix = "readiness.k8s.io/bootstrap-completed-"
+)
+
+// bootstrapCompletion records when a rule completed bootstrap on a node and
+// the node state that the rule's rearmOn policy watches. A record with
+// RearmedAt set marks a rule that was re-armed and has not completed since.
+type bootstrapCompletion struct {
+	Rule           string            `json:"rule"`
+	CompletedAt    metav1.Time       `json:"completedAt,omitempty"`
+	BootID         string            `json:"bootID,omitempty"`
+	KubeletVersion string            `json:"kubeletVersion,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	RearmedAt      *metav1.Time      `json:"rearmedAt,omitempty"`
+}
+
+// legacyBootstrapAnnotationKey returns the per-rule annotation used before completions were keyed by rule UID
+func legacyBootstrapAnnotationKey(ruleName string) string {
+	return legacyBootstrapAnnotationPrefix + ruleName
+}
+
+// getBootstrapCompletions parses the bootstrap completion annotation of a
+// node. A missing or malformed annotation means no rule has completed.
+func getBootstrapCompletions(node *corev1.Node) map[types.UID]bootstrapCompletion {
+	completions := make(map[types.UID]bootstrapCompletion)
+	if value, exists := node.Annotations[bootstrapCompletedAnnotation]; exists {
+		_ = json.Unmarshal([]byte(value), &completions)
+	}
+	return completions
+}
+
+// setBootstrapCompletions writes the bootstrap completion annotation, dropping it once empty
+func setBootstrapCompletions(node *corev1.Node, completions map[types.UID]bootstrapCompletion) {
+	if len(completions) == 0 {
+		delete(node.Annotations, bootstrapCompletedAnnotation)
+		return
+	}
+
+	value, err := json.Marshal(completions)
+	if err != nil {
+		return
+	}
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[bootstrapCompletedAnnotation] = string(value)
+}
+
+// getBootstrapCompletion returns the bootstrap completion of a rule on a node,
+// falling back to the legacy per-rule annotation
+func getBootstrapCompletion(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) (bootstrapCompletion, bool) {
+	if completion, exists := getBootstrapCompletions(node)[rule.UID]; exists {
+		return completion, true
+	}
+
+	value, exists := node.Annotations[legacyBootstrapAnnotationKey(rule.Name)]
+	if !exists {
+		return bootstrapCompletion{}, false
+	}
+	return parseLegacyBootstrapCompletion(rule.Name, value), true
+}
+
+// parseLegacyBootstrapCompletion reads the value of a legacy completion
+// annotation: either "true" or a JSON record of the node state
+func parseLegacyBootstrapCompletion(ruleName, value string) bootstrapCompletion {
+	var completion bootstrapCompletion
+	_ = json.Unmarshal([]byte(value), &completion)
+	completion.Rule = ruleName
+	return completion
+}
+
+// recordBootstrapCompletion marks the rule as completed on the node, replacing
+// any completion recorded for an earlier rule of the same name
+func recordBootstrapCompletion(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule, completion bootstrapCompletion) {
+	completions := getBootstrapCompletions(node)
+	for uid, existing := range completions {
+		if existing.Rule == rule.Name && uid != rule.UID {
+			delete(completions, uid)
+		}
+	}
+	completions[rule.UID] = completion
+	setBootstrapCompletions(node, completions)
+	delete(node.Annotations, legacyBootstrapAnnotationKey(rule.Name))
+}
+
+// forgetBootstrapCompletion drops the rule's completion from the node. It
+// returns false if the rule had not completed on the node.
+func forgetBootstrapCompletion(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	if _, exists := getBootstrapCompletion(node, rule); !exists {
+		return false
+	}
+
+	completions := getBootstrapCompletions(node)
+	delete(completions, rule.UID)
+	setBootstrapCompletions(node, completions)
+	delete(node.Annotations, legacyBootstrapAnnotationKey(rule.Name))
+	return true
+}
+
+// isBootstrapCompleted checks if a bootstrap-only rule has completed on a
+// node. It reads the node object the caller already holds, which the informer
+// keeps current, so no API call is made per rule.
+func isBootstrapCompleted(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	completion, exists := getBootstrapCompletion(node, rule)
+	return exists && completion.RearmedAt == nil
+}
+
+// bootstrapRearmedAt returns when a bootstrap-only rule was re-armed on a
+// node that has not completed it again since, or the zero time
+func bootstrapRearmedAt(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) time.Time {
+	if rule.Spec.EnforcementMode != readinessv1alpha1.EnforcementModeBootstrapOnly {
+		return time.Time{}
+	}
+	completion, exists := getBootstrapCompletions(node)[rule.UID]
+	if !exists || completion.RearmedAt == nil {
+		return time.Time{}
+	}
+	return completion.RearmedAt.Time
+}
+
+// newBootstrapCompletion captures the node state watched by the rule's rearmOn policy
+func newBootstrapCompletion(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) bootstrapCompletion {
+	completion := bootstrapCompletion{
+		Rule:           rule.Name,
+		CompletedAt:    metav1.Now(),
+		BootID:         node.Status.NodeInfo.BootID,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+	}
+	if rule.Spec.RearmOn != nil && len(rule.Spec.RearmOn.Labels) > 0 {
+		completion.Labels = make(map[string]string, len(rule.Spec.RearmOn.Labels))
+		for _, key := range rule.Spec.RearmOn.Labels {
+			completion.Labels[key] = node.Labels[key]
+		}
+	}
+	return completion
+}
+
+// bootstrapRearmReason returns why a completed bootstrap-only rule must be
+// re-applied to the node, or "" if it stays completed. Completions recorded
+// before rearmOn existed carry no node state and are never re-armed.
+func bootstrapRearmReason(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) string {
+	policy := rule.Spec.RearmOn
+	if policy == nil {
+		return ""
+	}
+
+	recorded, exists := getBootstrapCompletion(node, rule)
+	if !exists || recorded.RearmedAt != nil {
+		return ""
+	}
+
+	if policy.BootID && recorded.BootID != "" && recorded.BootID != node.Status.NodeInfo.BootID {
+		return "BootIDChanged"
+	}
+	if policy.KubeletVersion && recorded.KubeletVersion != "" &&
+		recorded.KubeletVersion != node.Status.NodeInfo.KubeletVersion {
+		return "KubeletVersionChanged"
+	}
+	for _, key := range policy.Labels {
+		if value, recordedLabel := recorded.Labels[key]; recordedLabel && value != node.Labels[key] {
+			return "LabelChanged"
+		}
+	}
+	return ""
+}
+
+func (r *ReadinessGateController) markBootstrapCompleted(ctx context.Context, nodeName string, rule *readinessv1alpha1.NodeReadinessGateRule) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// retry to handle conflict with concurrent node updates
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node := &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+			return err
+		}
+
+		// Check if already marked to avoid unnecessary updates
+		if isBootstrapCompleted(node, rule) {
+			return nil
+		}
+
+		// Record the node state so the rule can be re-armed when it changes
+		recordBootstrapCompletion(node, rule, newBootstrapCompletion(node, rule))
+		return r.Update(ctx, node)
+	})
+
+	if err != nil {
+		log.Error(err, "Failed to mark bootstrap completed", "node", nodeName, "rule", rule.Name)
+	} else {
+		log.Info("Marked bootstrap completed", "node", nodeName, "rule", rule.Name)
+	}
+}
+
+// skipCompletedBootstrap reports whether a bootstrap-only rule has completed
+// on a node and must be left alone. A node that changed in a way that re-arms
+// the rule is re-armed instead, and the caller's node object is updated.
+func (r *ReadinessGateController) skipCompletedBootstrap(ctx context.Context, node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if rule.Spec.EnforcementMode != readinessv1alpha1.EnforcementModeBootstrapOnly || !isBootstrapCompleted(node, rule) {
+		return false, nil
+	}
+
+	reason := bootstrapRearmReason(node, rule)
+	if reason == "" {
+		log.Info("Skipping bootstrap-only rule - already completed",
+			"node", node.Name, "rule", rule.Name)
+		return true, nil
+	}
+
+	log.Info("Re-arming bootstrap-only rule", "node", node.Name, "rule", rule.Name, "reason", reason)
+	if err := r.rearmBootstrap(ctx, node, rule); err != nil {
+		return true, err
+	}
+	return false, nil
+}
+
+// rearmBootstrap replaces the bootstrap completion of a rule with a re-arm
+// mark, so that conditions reported before now, e.g. before a reboot, do not
+// complete the rule again. The caller's node object is updated in place.
+func (r *ReadinessGateController) rearmBootstrap(ctx context.Context, node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: node.Name}, latest); err != nil {
+			return err
+		}
+
+		recordBootstrapCompletion(latest, rule, bootstrapCompletion{
+			Rule:      rule.Name,
+			RearmedAt: &metav1.Time{Time: time.Now()},
+		})
+		if err := r.Update(ctx, latest); err != nil {
+			return err
+		}
+		latest.DeepCopyInto(node)
+		return nil
+	})
+}
+
+// clearBootstrapCompleted removes the bootstrap completion mark of a rule from a node
+func (r *ReadinessGateController) clearBootstrapCompleted(ctx context.Context, nodeName string, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node := &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+			return err
+		}
+
+		if !forgetBootstrapCompletion(node, rule) {
+			return nil
+		}
+		return r.Update(ctx, node)
+	})
+}
+
+// migrateBootstrapAnnotations moves legacy per-rule completion annotations of
+// known rules into the structured annotation. Legacy annotations of rules
+// that no longer exist are dropped; those of rules that are not cached yet
+// are left for a later pass.
+func (r *ReadinessGateController) migrateBootstrapAnnotations(ctx context.Context, node *corev1.Node) error {
+	var legacyRules []*readinessv1alpha1.NodeReadinessGateRule
+	var staleKeys []string
+	for key := range node.Annotations {
+		ruleName, isLegacy := strings.CutPrefix(key, legacyBootstrapAnnotationPrefix)
+		if !isLegacy {
+			continue
+		}
+		if rule := r.getCachedRule(ruleName); rule != nil {
+			legacyRules = append(legacyRules, rule)
+			continue
+		}
+
+		// Reconciles only start once the informer cache has synced, so a rule
+		// missing from it was deleted
+		err := r.Get(ctx, client.ObjectKey{Name: ruleName}, &readinessv1alpha1.NodeReadinessGateRule{})
+		if apierrors.IsNotFound(err) {
+			staleKeys = append(staleKeys, key)
+		}
+	}
+	if len(legacyRules) == 0 && len(staleKeys) == 0 {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Migrating legacy bootstrap completion annotations", "node", node.Name,
+		"rules", len(legacyRules), "deletedRules", len(staleKeys))
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: node.Name}, latest); err != nil {
+			return err
+		}
+
+		for _, rule := range legacyRules {
+			value, exists := latest.Annotations[legacyBootstrapAnnotationKey(rule.Name)]
+			if !exists {
+				continue
+			}
+			recordBootstrapCompletion(latest, rule, parseLegacyBootstrapCompletion(rule.Name, value))
+		}
+		for _, key := range staleKeys {
+			delete(latest.Annotations, key)
+		}
+		if err := r.Update(ctx, latest); err != nil {
+			return err
+		}
+
+		latest.DeepCopyInto(node)
+		return nil
+	})
+}
+
+// cleanupBootstrapStateForRule removes the completion records of a deleted rule from all nodes
+func (r *ReadinessGateController) cleanupBootstrapStateForRule(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var errors []string
+	for _, node := range nodeList.Items {
+		if _, exists := getBootstrapCompletion(&node, rule); !exists {
+			continue
+		}
+
+		if err := r.clearBootstrapCompleted(ctx, node.Name, rule); err != nil {
+			errors = append(errors, fmt.Sprintf("node %s: %v", node.Name, err))
+		}
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to cleanup bootstrap state on some nodes: %s", strings.Join(errors, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// canaryRecheck is how often a rule whose canary nodes do not all satisfy it
+// is re-evaluated for promotion
+const canaryRecheck = time.Minute
+
+// canaryState is the in-memory copy of the canary promotion of a rule,
+// consulted when node events are processed
+type canaryState struct {
+	generation int64
+	promoted   bool
+}
+
+// canaryBucket places a node in one of 100 buckets, stable for a given rule
+func canaryBucket(ruleName, nodeName string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(ruleName + "/" + nodeName))
+	return int(hash.Sum32() % 100)
+}
+
+// canaryActive checks if a rule is enforced only on its canary nodes. Until
+// the rule is reconciled, its promotion is not known and the canary is
+// assumed to be active.
+func (r *ReadinessGateController) canaryActive(rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	if rule.Spec.Canary == nil {
+		return false
+	}
+
+	r.canariesMutex.Lock()
+	defer r.canariesMutex.Unlock()
+
+	state, known := r.canaries[rule.Name]
+	return !known || state.generation != rule.Generation || !state.promoted
+}
+
+// canaryReached checks if taints of a rule may be added to a node, i.e. the
+// rule has no active canary or the node is one of its canary nodes. Taint
+// removals are never held back.
+func (r *ReadinessGateController) canaryReached(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) bool {
+	return !r.canaryActive(rule) || r.isCanaryNode(rule, node)
+}
+
+// isCanaryNode checks if a node is one of the canary nodes of a rule
+func (r *ReadinessGateController) isCanaryNode(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) bool {
+	canary := rule.Spec.Canary
+	switch {
+	case canary.Selector != nil:
+		return r.compiledRuleFor(rule).canarySelector.Matches(labels.Set(node.Labels))
+	case canary.Percentage != nil:
+		return canaryBucket(rule.Name, node.Name) < int(*canary.Percentage)
+	}
+	return false
+}
+
+// syncCanary restores the canary promotion of a rule from its status, and
+// drops the canary status of rules without a canary
+func (r *ReadinessGateController) syncCanary(rule *readinessv1alpha1.NodeReadinessGateRule) {
+	if rule.Spec.Canary == nil {
+		rule.Status.Canary = nil
+		r.forgetCanary(rule.Name)
+		return
+	}
+
+	// Spec changes that do not change what is enforced keep the promotion
+	hash := evaluationHash(rule)
+	state := canaryState{generation: rule.Generation}
+	if status := rule.Status.Canary; status != nil && sameEvaluation(status.ObservedGeneration, status.EvaluationHash, rule, hash) {
+		status.ObservedGeneration, status.EvaluationHash = rule.Generation, hash
+		state.promoted = status.Promoted
+	}
+	r.setCanary(rule.Name, state)
+}
+
+// splitCanaryNodes separates the canary nodes of a rule from the nodes its
+// taints are held back on while the canary is active
+func (r *ReadinessGateController) splitCanaryNodes(
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	nodes []corev1.Node,
+) (canary, other []corev1.Node) {
+	if !r.canaryActive(rule) {
+		return nodes, nil
+	}
+
+	for _, node := range nodes {
+		if r.isCanaryNode(rule, &node) {
+			canary = append(canary, node)
+		} else {
+			other = append(other, node)
+		}
+	}
+	return canary, other
+}
+
+// updateCanary records the canary status of a rule after its canary nodes
+// were evaluated, and promotes the rule to full enforcement once every
+// canary node has satisfied it for promoteAfter. It returns whether the rule
+// was promoted, and otherwise when promotion should be checked again.
+func (r *ReadinessGateController) updateCanary(
+	ctx context.Context,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	canaryNodes []corev1.Node,
+) (bool, time.Duration) {
+	canary := rule.Spec.Canary
+	status := rule.Status.Canary
+	if status == nil || status.ObservedGeneration != rule.Generation {
+		status = &readinessv1alpha1.CanaryStatus{ObservedGeneration: rule.Generation, EvaluationHash: evaluationHash(rule)}
+		rule.Status.Canary = status
+	}
+	status.CanaryNodes = int32(len(canaryNodes))
+
+	if canary.PromoteAfter == nil {
+		status.CompliantSince = nil
+		return false, 0
+	}
+	if !r.nodesCompliant(rule.Name, canaryNodes) {
+		status.CompliantSince = nil
+		return false, canaryRecheck
+	}
+
+	now := time.Now()
+	if status.CompliantSince == nil {
+		status.CompliantSince = &metav1.Time{Time: now}
+	}
+	if wait := status.CompliantSince.Add(canary.PromoteAfter.Duration).Sub(now); wait > 0 {
+		return false, wait
+	}
+
+	status.Promoted = true
+	status.PromotionTime = &metav1.Time{Time: now}
+	r.setCanary(rule.Name, canaryState{generation: rule.Generation, promoted: true})
+
+	message := fmt.Sprintf("All %d canary nodes satisfied the rule for %s; enforcing on all matched nodes",
+		len(canaryNodes), canary.PromoteAfter.Duration)
+	ctrl.LoggerFrom(ctx).Info("Promoting canary", "rule", rule.Name, "canaryNodes", len(canaryNodes))
+	r.recordEvent(rule, corev1.EventTypeNormal, "CanaryPromoted", message)
+	return true, 0
+}
+
+// nodesCompliant checks if a rule is satisfied and not gated on every one of
+// the given nodes, judged by their latest outcomes
+func (r *ReadinessGateController) nodesCompliant(ruleName string, nodes []corev1.Node) bool {
+	if len(nodes) == 0 {
+		return false
+	}
+
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	outcomes := r.nodeOutcomes[ruleName]
+	for _, node := range nodes {
+		outcome, exists := outcomes[node.Name]
+		if !exists || !outcome.satisfied || outcome.gated {
+			return false
+		}
+	}
+	return true
+}
+
+// setCanary records the canary promotion of a rule
+func (r *ReadinessGateController) setCanary(ruleName string, state canaryState) {
+	r.canariesMutex.Lock()
+	defer r.canariesMutex.Unlock()
+
+	if r.canaries == nil {
+		r.canaries = make(map[string]canaryState)
+	}
+	r.canaries[ruleName] = state
+}
+
+// forgetCanary drops the canary promotion of a rule
+func (r *ReadinessGateController) forgetCanary(ruleName string) {
+	r.canariesMutex.Lock()
+	defer r.canariesMutex.Unlock()
+
+	delete(r.canaries, ruleName)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// compiledRule is the parsed form of one generation of a rule. It is built
+// once when the rule is cached, so node events do not re-parse the rule.
+type compiledRule struct {
+	generation int64
+
+	// selector is nil when the rule's node selector is invalid
+	selector    labels.Selector
+	selectorErr error
+
+	// conditionTypes are the node condition types the rule's conditions and
+	// condition groups refer to
+	conditionTypes sets.Set[string]
+
+	programs []compiledExpression
+
+	// canarySelector picks the canary nodes of a rule whose canary is chosen
+	// by label. An invalid canary selector picks no node.
+	canarySelector labels.Selector
+}
+
+// compileRule parses the node selector of a rule and compiles its condition
+// matchers and expressions. Errors are kept in the result rather than returned,
+// so that they can be reported in the rule status.
+func compileRule(rule *readinessv1alpha1.NodeReadinessGateRule) *compiledRule {
+	compiled := &compiledRule{
+		generation:     rule.Generation,
+		selector:       labels.Everything(),
+		conditionTypes: sets.New[string](),
+		programs:       compileExpressions(rule),
+	}
+
+	if rule.Spec.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(rule.Spec.NodeSelector)
+		if err != nil {
+			compiled.selector, compiled.selectorErr = nil, err
+		} else {
+			compiled.selector = selector
+		}
+	}
+
+	if canary := rule.Spec.Canary; canary != nil && canary.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(canary.Selector)
+		if err != nil {
+			selector = labels.Nothing()
+		}
+		compiled.canarySelector = selector
+	}
+
+	for _, condReq := range rule.Spec.Conditions {
+		compiled.conditionTypes.Insert(condReq.Type)
+	}
+	for _, group := range rule.Spec.ConditionGroups {
+		for _, condReq := range group.Conditions {
+			compiled.conditionTypes.Insert(condReq.Type)
+		}
+		for _, subGroup := range group.Groups {
+			for _, condReq := range subGroup.Conditions {
+				compiled.conditionTypes.Insert(condReq.Type)
+			}
+		}
+	}
+
+	return compiled
+}
+
+// matches checks if the rule applies to a node with the given labels. Rules
+// with an invalid selector match no node.
+func (c *compiledRule) matches(nodeLabels map[string]string) bool {
+	return c.selectorErr == nil && c.selector.Matches(labels.Set(nodeLabels))
+}
+
+// invalidExpressions counts the expressions that failed to compile
+func (c *compiledRule) invalidExpressions() int {
+	invalid := 0
+	for _, program := range c.programs {
+		if program.err != nil {
+			invalid++
+		}
+	}
+	return invalid
+}
+
+// nodeConditions indexes the node conditions the rule refers to by type
+func (c *compiledRule) nodeConditions(node *corev1.Node) map[string]*corev1.NodeCondition {
+	conditions := make(map[string]*corev1.NodeCondition, len(c.conditionTypes))
+	for i := range node.Status.Conditions {
+		condition := &node.Status.Conditions[i]
+		if c.conditionTypes.Has(string(condition.Type)) {
+			conditions[string(condition.Type)] = condition
+		}
+	}
+	return conditions
+}
+
+// compiledRuleFor returns the compiled form of a rule, compiling it on the
+// fly when the cache does not hold the rule's current generation
+func (r *ReadinessGateController) compiledRuleFor(rule *readinessv1alpha1.NodeReadinessGateRule) *compiledRule {
+	r.ruleCacheMutex.RLock()
+	defer r.ruleCacheMutex.RUnlock()
+
+	return r.cachedCompiledRule(rule)
+}
+
+// cachedCompiledRule is compiledRuleFor for callers that hold ruleCacheMutex
+func (r *ReadinessGateController) cachedCompiledRule(rule *readinessv1alpha1.NodeReadinessGateRule) *compiledRule {
+	if compiled, exists := r.compiledRules[rule.Name]; exists && compiled.generation == rule.Generation {
+		return compiled
+	}
+	return compileRule(rule)
+}
+
+// ruleSelector returns the parsed node selector of a rule
+func (r *ReadinessGateController) ruleSelector(rule *readinessv1alpha1.NodeReadinessGateRule) (labels.Selector, error) {
+	compiled := r.compiledRuleFor(rule)
+	if compiled.selectorErr != nil {
+		return nil, fmt.Errorf("invalid node selector: %w", compiled.selectorErr)
+	}
+	return compiled.selector, nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/google/cel-go/cel"
+	corev1 "k8s.io/api/core/v1"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+	"github.com/ajaysundark/node-readiness-gate-controller/internal/expression"
+)
+
+// conditionEvaluation is the outcome of evaluating a rule's conditions against a node
+type conditionEvaluation struct {
+	// satisfied is true when every top-level condition, group and expression is satisfied
+	satisfied         bool
+	conditionResults  []readinessv1alpha1.ConditionEvaluationResult
+	groupResults      []readinessv1alpha1.ConditionGroupResult
+	expressionResults []readinessv1alpha1.ExpressionEvaluationResult
+
+	// requeueAfter is non-zero when the outcome may change without a node
+	// update, e.g. when a missing condition's timeout expires
+	requeueAfter time.Duration
+
+	// satisfiedSince is the most recent lastTransitionTime among the satisfied
+	// conditions, i.e. how long the node has been in its current state
+	satisfiedSince time.Time
+
+	// reportedAfter is set while a re-armed bootstrap-only rule waits for the
+	// node to report its conditions again; older reports are stale
+	reportedAfter time.Time
+}
+
+// rearmRecheck is how often a re-armed rule checks for conditions reported
+// after the re-arm, since heartbeat updates do not trigger node events
+const rearmRecheck = 30 * time.Second
+
+type compiledExpression struct {
+	name    string
+	program cel.Program
+	err     error
+}
+
+// compileExpressions compiles every expression of a rule. Compilation errors
+// are kept per expression and reported when the expression is evaluated.
+func compileExpressions(rule *readinessv1alpha1.NodeReadinessGateRule) []compiledExpression {
+	programs := make([]compiledExpression, 0, len(rule.Spec.Expressions))
+	for _, expr := range rule.Spec.Expressions {
+		program, err := expression.Compile(expr.Expression)
+		programs = append(programs, compiledExpression{
+			name:    expr.Name,
+			program: program,
+			err:     err,
+		})
+	}
+	return programs
+}
+
+// evaluateConditions evaluates all conditions, condition groups and expressions of a rule against a node
+func (r *ReadinessGateController) evaluateConditions(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) conditionEvaluation {
+	compiled := r.compiledRuleFor(rule)
+	conditions := compiled.nodeConditions(node)
+	eval := conditionEvaluation{
+		satisfied:        true,
+		conditionResults: make([]readinessv1alpha1.ConditionEvaluationResult, 0, len(rule.Spec.Conditions)),
+		reportedAfter:    bootstrapRearmedAt(node, rule),
+	}
+
+	// Top-level conditions use ALL logic
+	for _, condReq := range rule.Spec.Conditions {
+		result, requeueAfter := r.evaluateCondition(node, conditions, condReq, "", eval.reportedAfter)
+		eval.requeueAfter = minRequeue(eval.requeueAfter, requeueAfter)
+		if !result.Satisfied {
+			eval.satisfied = false
+		}
+		eval.conditionResults = append(eval.conditionResults, result)
+	}
+
+	// Every group must be satisfied as well
+	for _, group := range rule.Spec.ConditionGroups {
+		if !r.evaluateConditionGroup(node, conditions, group, "", &eval) {
+			eval.satisfied = false
+		}
+	}
+
+	// And every expression must return true
+	if len(rule.Spec.Expressions) > 0 {
+		eval.expressionResults = r.evaluateExpressions(compiled.programs, node)
+		for _, result := range eval.expressionResults {
+			if !result.Satisfied {
+				eval.satisfied = false
+			}
+		}
+	}
+
+	eval.satisfiedSince = satisfiedSince(conditions, eval.conditionResults)
+
+	return eval
+}
+
+// satisfiedSince returns the latest lastTransitionTime of the satisfied
+// conditions reported by the node. Conditions without a transition time
+// and conditions satisfied through their missing policy are ignored.
+func satisfiedSince(conditions map[string]*corev1.NodeCondition, results []readinessv1alpha1.ConditionEvaluationResult) time.Time {
+	var since time.Time
+	for _, result := range results {
+		if !result.Satisfied || result.Missing {
+			continue
+		}
+		condition := conditions[result.Type]
+		if condition != nil && condition.LastTransitionTime.After(since) {
+			since = condition.LastTransitionTime.Time
+		}
+	}
+	return since
+}
+
+// evaluateExpressions runs a rule's compiled CEL programs against a node
+func (r *ReadinessGateController) evaluateExpressions(programs []compiledExpression, node *corev1.Node) []readinessv1alpha1.ExpressionEvaluationResult {
+	results := make([]readinessv1alpha1.ExpressionEvaluationResult, 0, len(programs))
+
+	input, inputErr := expression.NodeToInput(node)
+	for _, compiled := range programs {
+		result := readinessv1alpha1.ExpressionEvaluationResult{Name: compiled.name}
+
+		switch {
+		case compiled.err != nil:
+			result.Error = compiled.err.Error()
+		case inputErr != nil:
+			result.Error = inputErr.Error()
+		default:
+			satisfied, err := expression.Evaluate(compiled.program, input)
+			if err != nil {
+				result.Error = err.Error()
+			}
+			result.Satisfied = satisfied
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// evaluateConditionGroup evaluates a group and its nested groups, appending
+// the per-condition and per-group results to eval
+func (r *ReadinessGateController) evaluateConditionGroup(
+	node *corev1.Node,
+	conditions map[string]*corev1.NodeCondition,
+	group readinessv1alpha1.ConditionGroup,
+	parent string,
+	eval *conditionEvaluation,
+) bool {
+	name := group.Name
+	if parent != "" {
+		name = parent + "/" + group.Name
+	}
+
+	satisfiedCount := 0
+	for _, condReq := range group.Conditions {
+		result, requeueAfter := r.evaluateCondition(node, conditions, condReq, name, eval.reportedAfter)
+		eval.requeueAfter = minRequeue(eval.requeueAfter, requeueAfter)
+		if result.Satisfied {
+			satisfiedCount++
+		}
+		eval.conditionResults = append(eval.conditionResults, result)
+	}
+
+	for _, subGroup := range group.Groups {
+		nested := readinessv1alpha1.ConditionGroup{
+			Name:         subGroup.Name,
+			Operator:     subGroup.Operator,
+			MinSatisfied: subGroup.MinSatisfied,
+			Conditions:   subGroup.Conditions,
+		}
+		if r.evaluateConditionGroup(node, conditions, nested, name, eval) {
+			satisfiedCount++
+		}
+	}
+
+	total := len(group.Conditions) + len(group.Groups)
+	satisfied := groupSatisfied(group.Operator, group.MinSatisfied, satisfiedCount, total)
+
+	eval.groupResults = append(eval.groupResults, readinessv1alpha1.ConditionGroupResult{
+		Name:           name,
+		Operator:       group.Operator,
+		SatisfiedCount: satisfiedCount,
+		Total:          total,
+		Satisfied:      satisfied,
+	})
+
+	return satisfied
+}
+
+// evaluateCondition evaluates a single condition requirement against a node.
+// Conditions last reported before reportedAfter, if set, are stale. The
+// returned duration is non-zero when the result will change with time alone.
+func (r *ReadinessGateController) evaluateCondition(
+	node *corev1.Node,
+	conditions map[string]*corev1.NodeCondition,
+	condReq readinessv1alpha1.ConditionRequirement,
+	group string,
+	reportedAfter time.Time,
+) (readinessv1alpha1.ConditionEvaluationResult, time.Duration) {
+	result := readinessv1alpha1.ConditionEvaluationResult{
+		Type:           condReq.Type,
+		RequiredStatus: condReq.RequiredStatus,
+		Group:          group,
+	}
+
+	condition := conditions[condReq.Type]
+	if condition != nil {
+		result.CurrentStatus = condition.Status
+		result.Satisfied = condition.Status == condReq.RequiredStatus
+
+		// A condition from before the re-arm, e.g. left over from the
+		// previous boot, does not count until it is reported again
+		if result.Satisfied && !reportedAfter.IsZero() && lastReported(condition).Before(reportedAfter) {
+			result.Stale = true
+			result.Satisfied = false
+			return result, rearmRecheck
+		}
+
+		if condReq.MaxAge == nil || condReq.MaxAge.Duration <= 0 {
+			return result, 0
+		}
+
+		// Heartbeat updates do not trigger node events, so freshness is
+		// re-checked by requeueing at the expiry time
+		lastSeen := condition.LastHeartbeatTime.Time
+		if lastSeen.IsZero() {
+			lastSeen = condition.LastTransitionTime.Time
+		}
+		remaining := time.Until(lastSeen.Add(condReq.MaxAge.Duration))
+		if lastSeen.IsZero() || remaining <= 0 {
+			// Poll until the reporting agent refreshes the condition
+			result.Stale = true
+			result.Satisfied = false
+			return result, condReq.MaxAge.Duration
+		}
+		if result.Satisfied {
+			return result, remaining
+		}
+		return result, 0
+	}
+
+	// The node does not report the condition at all
+	result.CurrentStatus = corev1.ConditionUnknown
+	result.Missing = true
+
+	switch condReq.MissingPolicy {
+	case readinessv1alpha1.MissingPolicyTreatAsSatisfied:
+		result.Satisfied = true
+	case readinessv1alpha1.MissingPolicyTreatAsSatisfiedAfter:
+		if condReq.MissingTimeout == nil {
+			return result, 0
+		}
+		deadline := node.CreationTimestamp.Add(condReq.MissingTimeout.Duration)
+		if remaining := time.Until(deadline); remaining > 0 {
+			return result, remaining
+		}
+		result.Satisfied = true
+	}
+
+	return result, 0
+}
+
+// lastReported returns when a condition was last reported by its agent
+func lastReported(condition *corev1.NodeCondition) time.Time {
+	if condition.LastHeartbeatTime.After(condition.LastTransitionTime.Time) {
+		return condition.LastHeartbeatTime.Time
+	}
+	return condition.LastTransitionTime.Time
+}
+
+// groupSatisfied applies a group operator to the number of satisfied members
+func groupSatisfied(operator readinessv1alpha1.ConditionGroupOperator, minSatisfied, satisfiedCount, total int) bool {
+	switch operator {
+	case readinessv1alpha1.ConditionGroupOperatorAny:
+		return satisfiedCount > 0
+	case readinessv1alpha1.ConditionGroupOperatorAtLeast:
+		return satisfiedCount >= minSatisfied
+	default:
+		return satisfiedCount == total
+	}
+}
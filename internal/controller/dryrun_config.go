@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// globalDryRunKey is the ConfigMap key that toggles the global dry run at runtime
+const globalDryRunKey = "globalDryRun"
+
+// DryRunConfigReconciler applies the global dry run toggle stored in a ConfigMap
+type DryRunConfigReconciler struct {
+	client.Client
+	Controller *ReadinessGateController
+
+	// Namespace and Name identify the ConfigMap holding the toggle
+	Namespace string
+	Name      string
+
+	// Set while rules still need to be requeued after a toggle
+	requeuePending bool
+}
+
+// SetGlobalDryRun sets the global dry run mode (emergency off-switch) from
+// the command line
+func (r *ReadinessGateController) SetGlobalDryRun(dryRun bool) {
+	r.globalDryRun.Store(dryRun)
+}
+
+// setRuntimeDryRun sets the global dry run mode from the ConfigMap toggle,
+// returning true if it changed
+func (r *ReadinessGateController) setRuntimeDryRun(dryRun bool) bool {
+	return r.runtimeDryRun.Swap(dryRun) != dryRun
+}
+
+// isGlobalDryRun checks if taint changes are frozen for all rules
+func (r *ReadinessGateController) isGlobalDryRun() bool {
+	return r.globalDryRun.Load() || r.runtimeDryRun.Load()
+}
+
+// globalDryRunSource describes what enabled the global dry run
+func (r *ReadinessGateController) globalDryRunSource() string {
+	if r.globalDryRun.Load() {
+		return "the --global-dry-run flag"
+	}
+	return "the global dry run ConfigMap"
+}
+
+// requeueAllRules reconciles every rule again, e.g. so that enforcement
+// resumes and status reflects the global dry run after it is toggled
+func (r *ReadinessGateController) requeueAllRules(ctx context.Context) error {
+	if r.ruleEvents == nil {
+		return nil
+	}
+
+	ruleList := &readinessv1alpha1.NodeReadinessGateRuleList{}
+	if err := r.List(ctx, ruleList); err != nil {
+		return fmt.Errorf("failed to list rules: %w", err)
+	}
+
+	for i := range ruleList.Items {
+		select {
+		case r.ruleEvents <- event.TypedGenericEvent[*readinessv1alpha1.NodeReadinessGateRule]{Object: &ruleList.Items[i]}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+
+// Reconcile applies the toggle stored in the ConfigMap and requeues every rule
+// when it changes. The ConfigMap may live in any namespace, so reading it is
+// granted by the manager ClusterRole rather than the leader election Role.
+func (r *DryRunConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// A missing ConfigMap or key means enforcement is on
+	dryRun := false
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, req.NamespacedName, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	} else if value, exists := configMap.Data[globalDryRunKey]; exists {
+		parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			// Keep the current mode rather than guess during an incident
+			log.Error(err, "Invalid global dry run toggle, keeping the current mode",
+				"configMap", req.NamespacedName, "key", globalDryRunKey, "value", value)
+			return ctrl.Result{}, nil
+		}
+		dryRun = parsed
+	}
+
+	if r.Controller.setRuntimeDryRun(dryRun) {
+		log.Info("Global dry run toggled", "configMap", req.NamespacedName, "enabled", dryRun,
+			"effective", r.Controller.isGlobalDryRun())
+		r.requeuePending = true
+	}
+	if !r.requeuePending {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Controller.requeueAllRules(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.requeuePending = false
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DryRunConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.Namespace && obj.GetName() == r.Name
+		}))).
+		Named("dryrunconfig").
+		Complete(r)
+}
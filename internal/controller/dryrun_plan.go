@@ -0,0 +1,369 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+const (
+	// dryRunRefreshInterval is how often the dry run plan of a rule is
+	// rebuilt from all its nodes, on top of the updates from node events
+	dryRunRefreshInterval = 5 * time.Minute
+
+	// maxDryRunPlanNodes caps the number of planned nodes sampled in rule status
+	maxDryRunPlanNodes = 20
+
+	// dryRunPlanConfigMapPrefix prefixes the name of the ConfigMap that
+	// holds the full dry run plan of a rule
+	dryRunPlanConfigMapPrefix = "nrg-dry-run-"
+
+	// maxDryRunPlanBytes keeps a published plan well below the ConfigMap size limit
+	maxDryRunPlanBytes = 900 * 1024
+)
+
+// nodeSimulation is the change a rule would make to one node
+type nodeSimulation struct {
+	satisfied, gated bool
+	missing, unknown bool
+
+	// action is empty when nothing would change
+	action            readinessv1alpha1.DryRunAction
+	failingConditions []string
+}
+
+// simulateNode evaluates a rule against a node without changing it
+func (r *ReadinessGateController) simulateNode(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) nodeSimulation {
+	eval := r.evaluateConditions(rule, node)
+	gated, hasAllTaints := r.gateApplied(node, rule)
+
+	sim := nodeSimulation{satisfied: eval.satisfied, gated: gated}
+	for _, result := range eval.conditionResults {
+		if result.Missing {
+			sim.missing = true
+		} else if result.CurrentStatus == corev1.ConditionUnknown {
+			sim.unknown = true
+		}
+	}
+
+	if eval.satisfied && gated {
+		sim.action = readinessv1alpha1.DryRunActionUntaint
+	} else if !eval.satisfied && !hasAllTaints {
+		sim.action = readinessv1alpha1.DryRunActionTaint
+		sim.failingConditions = failingConditions(eval)
+	}
+	return sim
+}
+
+// failingConditions describes the top-level conditions, condition groups
+// and expressions a node does not satisfy
+func failingConditions(eval conditionEvaluation) []string {
+	var failing []string
+	for _, result := range eval.conditionResults {
+		if result.Satisfied || result.Group != "" {
+			continue
+		}
+		switch {
+		case result.Missing:
+			failing = append(failing, fmt.Sprintf("%s: missing", result.Type))
+		case result.Stale:
+			failing = append(failing, fmt.Sprintf("%s: stale", result.Type))
+		default:
+			failing = append(failing, fmt.Sprintf("%s: %s, want %s", result.Type, result.CurrentStatus, result.RequiredStatus))
+		}
+	}
+
+	// Nested groups are covered by their top-level group
+	for _, group := range eval.groupResults {
+		if !group.Satisfied && !strings.Contains(group.Name, "/") {
+			failing = append(failing, fmt.Sprintf("group %s: %d of %d satisfied (%s)",
+				group.Name, group.SatisfiedCount, group.Total, group.Operator))
+		}
+	}
+
+	for _, result := range eval.expressionResults {
+		switch {
+		case result.Error != "":
+			failing = append(failing, fmt.Sprintf("expression %s: %s", result.Name, result.Error))
+		case !result.Satisfied:
+			failing = append(failing, fmt.Sprintf("expression %s: false", result.Name))
+		}
+	}
+	return failing
+}
+
+// add counts the change a rule would make to a node
+func (sim *ruleSimulation) add(nodeName string, node nodeSimulation) {
+	if sim.nodes == nil {
+		sim.nodes = make(map[string]nodeSimulation)
+	}
+	sim.nodes[nodeName] = node
+
+	sim.affectedNodes++
+	if node.gated {
+		sim.gatedNodes++
+	}
+	switch node.action {
+	case readinessv1alpha1.DryRunActionTaint:
+		sim.taintsToAdd++
+	case readinessv1alpha1.DryRunActionUntaint:
+		sim.taintsToRemove++
+	}
+	if node.missing {
+		sim.missingNodes++
+	}
+	if node.unknown {
+		sim.unknownNodes++
+	}
+	if node.missing || node.unknown {
+		sim.riskyOps++
+	}
+}
+
+// plan lists the nodes whose taints would change, in node name order
+func (sim *ruleSimulation) plan() []readinessv1alpha1.NodeDryRunPlan {
+	var plan []readinessv1alpha1.NodeDryRunPlan
+	for nodeName, node := range sim.nodes {
+		if node.action == "" {
+			continue
+		}
+		plan = append(plan, readinessv1alpha1.NodeDryRunPlan{
+			NodeName:          nodeName,
+			Action:            node.action,
+			FailingConditions: node.failingConditions,
+		})
+	}
+	slices.SortFunc(plan, func(a, b readinessv1alpha1.NodeDryRunPlan) int {
+		return strings.Compare(a.NodeName, b.NodeName)
+	})
+	return plan
+}
+
+// reportsDryRun checks if a rule reports a dry run plan for some of its
+// nodes instead of, or besides, enforcing it
+func (r *ReadinessGateController) reportsDryRun(rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	return rule.Spec.DryRun || r.isGlobalDryRun() || r.canaryActive(rule)
+}
+
+// recordDryRunPlan keeps the simulated changes to the nodes of a rule in
+// their outcomes, where node events update them
+func (r *ReadinessGateController) recordDryRunPlan(ruleName string, sim ruleSimulation) {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	outcomes := r.ruleNodeOutcomes(ruleName)
+	for nodeName, node := range sim.nodes {
+		outcomes[nodeName] = nodeOutcome{satisfied: node.satisfied, gated: node.gated, dryRun: &node}
+	}
+}
+
+// evaluateDryRunForNode updates the dry run plan of a rule after a node changed
+func (r *ReadinessGateController) evaluateDryRunForNode(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) {
+	sim := r.simulateNode(rule, node)
+	r.recordNodeOutcome(rule.Name, node.Name, nodeOutcome{satisfied: sim.satisfied, gated: sim.gated, dryRun: &sim})
+}
+
+// dryRunPlanSimulation collects the latest dry run plan of a rule from its
+// node outcomes
+func (r *ReadinessGateController) dryRunPlanSimulation(ruleName string) ruleSimulation {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	var sim ruleSimulation
+	for nodeName, outcome := range r.nodeOutcomes[ruleName] {
+		if outcome.dryRun != nil {
+			sim.add(nodeName, *outcome.dryRun)
+		}
+	}
+	return sim
+}
+
+// reportDryRun writes the dry run plan of a rule into its status, sampling
+// the planned nodes, and publishes the full plan
+func (r *ReadinessGateController) reportDryRun(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) {
+	sim := r.dryRunPlanSimulation(rule.Name)
+	results := dryRunResults(sim)
+
+	if r.dryRunPlanNamespace != "" {
+		results.PlanConfigMap = r.dryRunPlanNamespace + "/" + dryRunPlanConfigMapName(rule.Name)
+		if err := r.publishDryRunPlan(ctx, rule, sim.plan(), results.Summary); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to publish dry run plan", "rule", rule.Name,
+				"configMap", results.PlanConfigMap)
+		}
+	}
+	rule.Status.DryRunResults = results
+}
+
+// SetDryRunPlanNamespace sets the namespace the full dry run plan of each
+// rule is published in. Empty disables publishing.
+func (r *ReadinessGateController) SetDryRunPlanNamespace(namespace string) {
+	r.dryRunPlanNamespace = namespace
+}
+
+// dryRunPlanConfigMapName returns the name of the ConfigMap holding the full
+// dry run plan of a rule. Rule names too long for a ConfigMap name are
+// shortened and suffixed with a hash of the full name.
+func dryRunPlanConfigMapName(ruleName string) string {
+	const maxNameLength = validation.DNS1123SubdomainMaxLength - len(dryRunPlanConfigMapPrefix)
+	if len(ruleName) <= maxNameLength {
+		return dryRunPlanConfigMapPrefix + ruleName
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(ruleName))
+	suffix := fmt.Sprintf("-%08x", hash.Sum32())
+	// A DNS label may not end in a dash before the dot or suffix
+	return dryRunPlanConfigMapPrefix + strings.TrimRight(ruleName[:maxNameLength-len(suffix)], ".-") + suffix
+}
+
+// dryRunPlanData renders a plan as ConfigMap data, dropping the last nodes
+// if the plan would not fit
+func dryRunPlanData(plan []readinessv1alpha1.NodeDryRunPlan, summary string) (map[string]string, error) {
+	if plan == nil {
+		plan = []readinessv1alpha1.NodeDryRunPlan{}
+	}
+
+	total := len(plan)
+	for {
+		encoded, err := json.Marshal(plan)
+		if err != nil {
+			return nil, err
+		}
+		if len(encoded) <= maxDryRunPlanBytes {
+			data := map[string]string{
+				"summary":   summary,
+				"plan.json": string(encoded),
+			}
+			if omitted := total - len(plan); omitted > 0 {
+				data["omittedNodes"] = strconv.Itoa(omitted)
+			}
+			return data, nil
+		}
+		plan = plan[:len(plan)*9/10]
+	}
+}
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update;delete
+
+// publishDryRunPlan writes the full dry run plan of a rule into its
+// ConfigMap. The ConfigMap is owned by the rule, so it is garbage collected
+// with it. Plans that did not change since they were last published are
+// not written again.
+func (r *ReadinessGateController) publishDryRunPlan(
+	ctx context.Context,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	plan []readinessv1alpha1.NodeDryRunPlan,
+	summary string,
+) error {
+	data, err := dryRunPlanData(plan, summary)
+	if err != nil {
+		return err
+	}
+
+	hash := fnv.New64a()
+	for _, key := range []string{"summary", "plan.json", "omittedNodes"} {
+		_, _ = hash.Write([]byte(key + "=" + data[key] + "\n"))
+	}
+	digest := hash.Sum64()
+
+	r.dryRunPlansMutex.Lock()
+	published, exists := r.publishedPlans[rule.Name]
+	r.dryRunPlansMutex.Unlock()
+	if exists && published == digest {
+		return nil
+	}
+
+	ownerReferences := []metav1.OwnerReference{{
+		APIVersion: readinessv1alpha1.GroupVersion.String(),
+		Kind:       "NodeReadinessGateRule",
+		Name:       rule.Name,
+		UID:        rule.UID,
+	}}
+
+	configMaps := r.clientset.CoreV1().ConfigMaps(r.dryRunPlanNamespace)
+	name := dryRunPlanConfigMapName(rule.Name)
+	configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       r.dryRunPlanNamespace,
+				OwnerReferences: ownerReferences,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+	} else if err == nil && (!equality.Semantic.DeepEqual(configMap.Data, data) ||
+		!equality.Semantic.DeepEqual(configMap.OwnerReferences, ownerReferences)) {
+		configMap.Data = data
+		configMap.OwnerReferences = ownerReferences
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	r.dryRunPlansMutex.Lock()
+	defer r.dryRunPlansMutex.Unlock()
+	if r.publishedPlans == nil {
+		r.publishedPlans = make(map[string]uint64)
+	}
+	r.publishedPlans[rule.Name] = digest
+	return nil
+}
+
+// deleteDryRunPlan deletes the ConfigMap a rule published its dry run plan
+// in, once the rule is enforced on all its nodes
+func (r *ReadinessGateController) deleteDryRunPlan(ctx context.Context, ruleName string, results *readinessv1alpha1.DryRunResults) error {
+	r.forgetDryRunPlan(ruleName)
+	if results == nil || results.PlanConfigMap == "" {
+		return nil
+	}
+
+	namespace, name, found := strings.Cut(results.PlanConfigMap, "/")
+	if !found {
+		return nil
+	}
+	err := r.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// forgetDryRunPlan drops the record of the last plan published for a rule
+func (r *ReadinessGateController) forgetDryRunPlan(ruleName string) {
+	r.dryRunPlansMutex.Lock()
+	defer r.dryRunPlansMutex.Unlock()
+
+	delete(r.publishedPlans, ruleName)
+}
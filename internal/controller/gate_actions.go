@@ -0,0 +1,337 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+const (
+	// gateConditionPrefix prefixes the default condition type written by a rule's condition action
+	gateConditionPrefix = "ReadinessGates/"
+
+	// cordonedByAnnotationPrefix marks nodes cordoned by a rule, so that only
+	// the rule's own cordon is ever lifted
+	cordonedByAnnotationPrefix = "readiness.k8s.io/" + cordonedByAnnotationName
+	cordonedByAnnotationName   = "cordoned-by-"
+
+	// labeledByAnnotationPrefix marks nodes labeled by a rule, so that only
+	// the rule's own label is ever removed
+	labeledByAnnotationPrefix = "readiness.k8s.io/" + labeledByAnnotationName
+	labeledByAnnotationName   = "labeled-by-"
+)
+
+// gateConditionType returns the node condition type written by a rule's condition action
+func gateConditionType(rule *readinessv1alpha1.NodeReadinessGateRule) string {
+	if rule.Spec.Actions != nil && rule.Spec.Actions.Condition != nil && rule.Spec.Actions.Condition.Type != "" {
+		return rule.Spec.Actions.Condition.Type
+	}
+	return gateConditionPrefix + rule.Name
+}
+
+// gateApplied reports whether any and whether all of a rule's gate actions,
+// taints included, are in effect on a node. A condition action only counts
+// once it reports the node not ready; a node that does not report the
+// condition yet is not gated.
+func (r *ReadinessGateController) gateApplied(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) (anyApplied, allApplied bool) {
+	taints := rule.Spec.GetTaints()
+	anyApplied = r.hasAnyTaintBySpec(node, rule, taints)
+	allApplied = r.hasAllTaintsBySpec(node, rule, taints)
+
+	actions := rule.Spec.Actions
+	if actions == nil {
+		return anyApplied, allApplied
+	}
+
+	if actions.Cordon {
+		anyApplied = anyApplied || r.isCordonedByRule(node, rule.Name)
+		allApplied = allApplied && node.Spec.Unschedulable
+	}
+	if actions.Label != nil {
+		anyApplied = anyApplied || r.isLabeledByRule(node, rule.Name, *actions.Label)
+		allApplied = allApplied && r.hasLabelBySpec(node, *actions.Label)
+	}
+	if actions.Condition != nil {
+		notReady := r.hasConditionBySpec(node, gateConditionType(rule), corev1.ConditionFalse)
+		anyApplied = anyApplied || notReady
+		allApplied = allApplied && notReady
+	}
+
+	return anyApplied, allApplied
+}
+
+// applyGateActions adds the rule's taints and applies its other gate
+// actions. Taints, cordon and label are written in a single patch so that a
+// failure cannot leave the node partially gated; the condition lives in the
+// node status and is written afterwards.
+func (r *ReadinessGateController) applyGateActions(ctx context.Context, node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	if err := r.patchNode(ctx, node, func(node *corev1.Node) bool {
+		changed := false
+		if taints := rule.Spec.GetTaints(); !r.hasAllTaintsBySpec(node, rule, taints) {
+			r.addTaintsBySpec(node, rule, taints)
+			changed = true
+		}
+
+		actions := rule.Spec.Actions
+		if actions == nil {
+			return changed
+		}
+		if actions.Cordon && !node.Spec.Unschedulable {
+			r.cordonNode(node, rule.Name)
+			changed = true
+		}
+		if actions.Label != nil && !r.hasLabelBySpec(node, *actions.Label) {
+			r.addLabelBySpec(node, rule.Name, *actions.Label)
+			changed = true
+		}
+		return changed
+	}); err != nil {
+		return fmt.Errorf("failed to apply gate actions: %w", err)
+	}
+
+	if conditionType := gateConditionType(rule); rule.Spec.Actions != nil && rule.Spec.Actions.Condition != nil &&
+		!r.hasConditionBySpec(node, conditionType, corev1.ConditionFalse) {
+		if err := r.setConditionBySpec(ctx, node, conditionType, corev1.ConditionFalse,
+			"RuleNotSatisfied", fmt.Sprintf("Node does not satisfy rule %s", rule.Name)); err != nil {
+			return fmt.Errorf("failed to set condition: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// removeGateActions removes the rule's taints and reverts its other gate
+// actions. The condition action is set to True rather than removed.
+func (r *ReadinessGateController) removeGateActions(ctx context.Context, node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	if err := r.liftGateActions(ctx, node, rule); err != nil {
+		return err
+	}
+
+	if conditionType := gateConditionType(rule); rule.Spec.Actions != nil && rule.Spec.Actions.Condition != nil &&
+		!r.hasConditionBySpec(node, conditionType, corev1.ConditionTrue) {
+		if err := r.setConditionBySpec(ctx, node, conditionType, corev1.ConditionTrue,
+			"RuleSatisfied", fmt.Sprintf("Node satisfies rule %s", rule.Name)); err != nil {
+			return fmt.Errorf("failed to set condition: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// cleanupGateActions removes every trace of a rule from a node that the rule
+// no longer manages, including its taint ownership record and the condition
+// written by its condition action
+func (r *ReadinessGateController) cleanupGateActions(ctx context.Context, node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	if err := r.patchNode(ctx, node, func(node *corev1.Node) bool {
+		lifted := r.liftActions(node, rule)
+		return forgetTaintOwner(node, rule) || lifted
+	}); err != nil {
+		return fmt.Errorf("failed to lift gate actions: %w", err)
+	}
+
+	if r.hasGateCondition(node, rule) {
+		if err := r.removeConditionBySpec(ctx, node, gateConditionType(rule)); err != nil {
+			return fmt.Errorf("failed to remove condition: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// awaitsReadyCondition checks if the rule's condition action has not
+// reported the node ready yet, e.g. because the node does not report the
+// condition at all
+func (r *ReadinessGateController) awaitsReadyCondition(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	return rule.Spec.Actions != nil && rule.Spec.Actions.Condition != nil &&
+		!r.hasConditionBySpec(node, gateConditionType(rule), corev1.ConditionTrue)
+}
+
+// hasGateCondition checks if a node reports the condition written by the rule's condition action
+func (r *ReadinessGateController) hasGateCondition(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	return rule.Spec.Actions != nil && rule.Spec.Actions.Condition != nil &&
+		r.getCondition(node, gateConditionType(rule)) != nil
+}
+
+// liftGateActions removes the rule's owned taints, cordon and label in a
+// single patch
+func (r *ReadinessGateController) liftGateActions(ctx context.Context, node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	if err := r.patchNode(ctx, node, func(node *corev1.Node) bool {
+		return r.liftActions(node, rule)
+	}); err != nil {
+		return fmt.Errorf("failed to lift gate actions: %w", err)
+	}
+
+	return nil
+}
+
+// liftActions removes the rule's owned taints, cordon and label from the
+// node object, reporting whether anything changed
+func (r *ReadinessGateController) liftActions(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	changed := false
+	if taints := rule.Spec.GetTaints(); r.hasAnyTaintBySpec(node, rule, taints) {
+		r.removeTaintsBySpec(node, rule, taints)
+		changed = true
+	}
+
+	actions := rule.Spec.Actions
+	if actions == nil {
+		return changed
+	}
+	if r.isCordonedByRule(node, rule.Name) {
+		r.uncordonNode(node, rule.Name)
+		changed = true
+	}
+	if actions.Label != nil && r.isLabeledByRule(node, rule.Name, *actions.Label) {
+		r.removeLabelBySpec(node, rule.Name, *actions.Label)
+		changed = true
+	}
+	return changed
+}
+
+// cordonAnnotationKey returns the annotation that marks a node cordoned by a rule
+func cordonAnnotationKey(ruleName string) string {
+	return ruleAnnotationKey(cordonedByAnnotationPrefix, cordonedByAnnotationName, ruleName)
+}
+
+// labelAnnotationKey returns the annotation that marks a node labeled by a rule
+func labelAnnotationKey(ruleName string) string {
+	return ruleAnnotationKey(labeledByAnnotationPrefix, labeledByAnnotationName, ruleName)
+}
+
+// ruleAnnotationKey appends a rule name to an annotation prefix. Rule names
+// too long for an annotation name are shortened and suffixed with a hash of
+// the full name.
+func ruleAnnotationKey(prefix, name, ruleName string) string {
+	// The name part of an annotation key is limited to 63 characters
+	maxNameLength := 63 - len(name)
+	if len(ruleName) <= maxNameLength {
+		return prefix + ruleName
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(ruleName))
+	suffix := fmt.Sprintf("-%08x", hash.Sum32())
+	return prefix + ruleName[:maxNameLength-len(suffix)] + suffix
+}
+
+// isCordonedByRule checks if a node is cordoned and the cordon was set by the given rule
+func (r *ReadinessGateController) isCordonedByRule(node *corev1.Node, ruleName string) bool {
+	_, owned := node.Annotations[cordonAnnotationKey(ruleName)]
+	return node.Spec.Unschedulable && owned
+}
+
+// cordonNode marks a node unschedulable and records the rule that did so
+func (r *ReadinessGateController) cordonNode(node *corev1.Node, ruleName string) {
+	node.Spec.Unschedulable = true
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[cordonAnnotationKey(ruleName)] = "true"
+}
+
+// uncordonNode lifts a cordon set by the given rule
+func (r *ReadinessGateController) uncordonNode(node *corev1.Node, ruleName string) {
+	node.Spec.Unschedulable = false
+	delete(node.Annotations, cordonAnnotationKey(ruleName))
+}
+
+// hasLabelBySpec checks if a node has a specific label
+func (r *ReadinessGateController) hasLabelBySpec(node *corev1.Node, labelAction readinessv1alpha1.LabelAction) bool {
+	value, exists := node.Labels[labelAction.Key]
+	return exists && value == labelAction.Value
+}
+
+// isLabeledByRule checks if a node has a label and the label was set by the given rule
+func (r *ReadinessGateController) isLabeledByRule(node *corev1.Node, ruleName string, labelAction readinessv1alpha1.LabelAction) bool {
+	_, owned := node.Annotations[labelAnnotationKey(ruleName)]
+	return r.hasLabelBySpec(node, labelAction) && owned
+}
+
+// addLabelBySpec adds a label to a node and records the rule that did so
+func (r *ReadinessGateController) addLabelBySpec(node *corev1.Node, ruleName string, labelAction readinessv1alpha1.LabelAction) {
+	if node.Labels == nil {
+		node.Labels = make(map[string]string)
+	}
+	node.Labels[labelAction.Key] = labelAction.Value
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[labelAnnotationKey(ruleName)] = "true"
+}
+
+// removeLabelBySpec removes a label set by the given rule from a node
+func (r *ReadinessGateController) removeLabelBySpec(node *corev1.Node, ruleName string, labelAction readinessv1alpha1.LabelAction) {
+	delete(node.Labels, labelAction.Key)
+	delete(node.Annotations, labelAnnotationKey(ruleName))
+}
+
+// hasConditionBySpec checks if a node reports a condition with the given status
+func (r *ReadinessGateController) hasConditionBySpec(node *corev1.Node, conditionType string, status corev1.ConditionStatus) bool {
+	condition := r.getCondition(node, conditionType)
+	return condition != nil && condition.Status == status
+}
+
+// setConditionBySpec writes a condition to the node status
+func (r *ReadinessGateController) setConditionBySpec(
+	ctx context.Context,
+	node *corev1.Node,
+	conditionType string,
+	status corev1.ConditionStatus,
+	reason, message string,
+) error {
+	return r.patchNodeStatus(ctx, node, func(node *corev1.Node) bool {
+		now := metav1.Now()
+
+		condition := r.getCondition(node, conditionType)
+		if condition == nil {
+			node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+				Type:               corev1.NodeConditionType(conditionType),
+				LastTransitionTime: now,
+			})
+			condition = &node.Status.Conditions[len(node.Status.Conditions)-1]
+		} else if condition.Status != status {
+			condition.LastTransitionTime = now
+		}
+		condition.Status = status
+		condition.LastHeartbeatTime = now
+		condition.Reason = reason
+		condition.Message = message
+		return true
+	})
+}
+
+// removeConditionBySpec removes a condition from the node status
+func (r *ReadinessGateController) removeConditionBySpec(ctx context.Context, node *corev1.Node, conditionType string) error {
+	return r.patchNodeStatus(ctx, node, func(node *corev1.Node) bool {
+		var newConditions []corev1.NodeCondition
+		for _, condition := range node.Status.Conditions {
+			if string(condition.Type) != conditionType {
+				newConditions = append(newConditions, condition)
+			}
+		}
+		changed := len(newConditions) != len(node.Status.Conditions)
+		node.Status.Conditions = newConditions
+		return changed
+	})
+}
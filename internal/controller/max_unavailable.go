@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// maxUnavailableRecheck is how soon a node whose taints were held back is
+// evaluated again
+const maxUnavailableRecheck = time.Minute
+
+// taintBreaker records why taint additions of a rule are held back
+type taintBreaker struct {
+	reason  string
+	message string
+}
+
+// gateCount counts matched and gated nodes
+type gateCount struct {
+	matched, gated int
+}
+
+// SetGlobalMaxUnavailable caps how many nodes all rules together may gate,
+// as an absolute number or a percentage of the nodes matched by any rule
+func (r *ReadinessGateController) SetGlobalMaxUnavailable(maxUnavailable *intstr.IntOrString) {
+	r.breakerMutex.Lock()
+	defer r.breakerMutex.Unlock()
+
+	r.globalMaxUnavailable = maxUnavailable
+}
+
+// maxUnavailableLimit resolves a maxUnavailable against a number of nodes.
+// Percentages round up, so that a small pool can still gate one node.
+func maxUnavailableLimit(maxUnavailable *intstr.IntOrString, total int) (int, bool) {
+	if maxUnavailable == nil {
+		return 0, false
+	}
+	limit, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, total, true)
+	if err != nil {
+		// Rejected by the webhook and at startup
+		return 0, false
+	}
+	return limit, true
+}
+
+// reserveTaintAddition checks that gating one more node keeps the rule and
+// the controller within their maxUnavailable, and counts the node as gated
+// if so, so that concurrent workers see it. Otherwise the rule's breaker is
+// opened and the taints are held back; removals are never limited. Until
+// the gated nodes are counted, e.g. after a restart, additions are held back
+// as well.
+func (r *ReadinessGateController) reserveTaintAddition(
+	ctx context.Context,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	nodeName string,
+) bool {
+	r.breakerMutex.Lock()
+	globalLimited := r.globalMaxUnavailable != nil
+	r.breakerMutex.Unlock()
+	if rule.Spec.MaxUnavailable == nil && !globalLimited {
+		return true
+	}
+
+	if !r.gateCountsComplete(rule.Name, globalLimited) {
+		ctrl.LoggerFrom(ctx).V(1).Info("Holding back taint additions until the gated nodes are counted",
+			"rule", rule.Name, "node", nodeName)
+		return false
+	}
+
+	r.breakerMutex.Lock()
+	ruleCount, globalCount := r.gateCountsWith(rule.Name, nodeName)
+	var breaker *taintBreaker
+	if limit, ok := maxUnavailableLimit(rule.Spec.MaxUnavailable, ruleCount.matched); ok && ruleCount.gated > limit {
+		breaker = &taintBreaker{
+			reason: "MaxUnavailableExceeded",
+			message: fmt.Sprintf("%d of %d matched nodes are gated and maxUnavailable is %s; holding back further taints",
+				ruleCount.gated-1, ruleCount.matched, rule.Spec.MaxUnavailable.String()),
+		}
+	} else if limit, ok := maxUnavailableLimit(r.globalMaxUnavailable, globalCount.matched); ok && globalCount.gated > limit {
+		breaker = &taintBreaker{
+			reason: "GlobalMaxUnavailableExceeded",
+			message: fmt.Sprintf("%d of %d nodes are gated by rules and the global maxUnavailable is %s; holding back further taints",
+				globalCount.gated-1, globalCount.matched, r.globalMaxUnavailable.String()),
+		}
+	}
+
+	if breaker == nil {
+		r.recordNodeOutcome(rule.Name, nodeName, nodeOutcome{gated: true})
+		r.breakerMutex.Unlock()
+		return true
+	}
+
+	opened := r.taintBreakers[rule.Name] == nil
+	if r.taintBreakers == nil {
+		r.taintBreakers = make(map[string]*taintBreaker)
+	}
+	r.taintBreakers[rule.Name] = breaker
+	r.breakerMutex.Unlock()
+
+	if opened {
+		ctrl.LoggerFrom(ctx).Info("Holding back taint additions", "rule", rule.Name, "reason", breaker.reason,
+			"message", breaker.message)
+		r.recordEvent(rule, corev1.EventTypeWarning, breaker.reason, breaker.message)
+		maxUnavailableBreakerOpen.WithLabelValues(rule.Name).Set(1)
+	}
+	return false
+}
+
+// refreshTaintBreaker closes the breaker of a rule once gating another node
+// would fit within maxUnavailable again
+func (r *ReadinessGateController) refreshTaintBreaker(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) {
+	r.breakerMutex.Lock()
+	if r.taintBreakers[rule.Name] == nil {
+		r.breakerMutex.Unlock()
+		return
+	}
+
+	ruleCount, globalCount := r.gateCountsWith(rule.Name, "")
+	if limit, ok := maxUnavailableLimit(rule.Spec.MaxUnavailable, ruleCount.matched); ok && ruleCount.gated >= limit {
+		r.breakerMutex.Unlock()
+		return
+	}
+	if limit, ok := maxUnavailableLimit(r.globalMaxUnavailable, globalCount.matched); ok && globalCount.gated >= limit {
+		r.breakerMutex.Unlock()
+		return
+	}
+	delete(r.taintBreakers, rule.Name)
+	r.breakerMutex.Unlock()
+
+	message := "Gated nodes are back within maxUnavailable; taint additions resume"
+	ctrl.LoggerFrom(ctx).Info("Resuming taint additions", "rule", rule.Name)
+	r.recordEvent(rule, corev1.EventTypeNormal, "TaintAdditionsResumed", message)
+	maxUnavailableBreakerOpen.WithLabelValues(rule.Name).Set(0)
+}
+
+// taintBreakerFor returns why taint additions of a rule are held back, if they are
+func (r *ReadinessGateController) taintBreakerFor(ruleName string) (taintBreaker, bool) {
+	r.breakerMutex.Lock()
+	defer r.breakerMutex.Unlock()
+
+	breaker, open := r.taintBreakers[ruleName]
+	if !open {
+		return taintBreaker{}, false
+	}
+	return *breaker, true
+}
+
+// forgetTaintBreaker drops the breaker of a deleted rule
+func (r *ReadinessGateController) forgetTaintBreaker(ruleName string) {
+	r.breakerMutex.Lock()
+	defer r.breakerMutex.Unlock()
+
+	delete(r.taintBreakers, ruleName)
+	maxUnavailableBreakerOpen.DeleteLabelValues(ruleName)
+}
+
+// gateCountsWith counts the matched and gated nodes of a rule and of all
+// rules, from the latest node outcomes. The given node, if any, is counted
+// as matched and about to be gated by the rule.
+func (r *ReadinessGateController) gateCountsWith(ruleName, nodeName string) (gateCount, gateCount) {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	matched, gated := sets.New[string](), sets.New[string]()
+	if nodeName != "" {
+		matched.Insert(nodeName)
+		gated.Insert(nodeName)
+	}
+
+	var ruleCount gateCount
+	for name, outcome := range r.nodeOutcomes[ruleName] {
+		if name == nodeName {
+			continue
+		}
+		ruleCount.matched++
+		if outcome.gated {
+			ruleCount.gated++
+		}
+	}
+	ruleCount.matched += matched.Len()
+	ruleCount.gated += gated.Len()
+
+	for _, nodes := range r.nodeOutcomes {
+		for name, outcome := range nodes {
+			matched.Insert(name)
+			if outcome.gated {
+				gated.Insert(name)
+			}
+		}
+	}
+	return ruleCount, gateCount{matched: matched.Len(), gated: gated.Len()}
+}
+
+// gateCountsComplete checks if the node outcomes maxUnavailable is checked
+// against cover all nodes of the rule and, for the global limit, of every
+// cached rule. Outcomes are only partial until a rule has been processed
+// once, and counting them would let additions through a restart.
+func (r *ReadinessGateController) gateCountsComplete(ruleName string, global bool) bool {
+	ruleNames := []string{ruleName}
+	if global {
+		r.ruleCacheMutex.RLock()
+		for name := range r.ruleCache {
+			ruleNames = append(ruleNames, name)
+		}
+		r.ruleCacheMutex.RUnlock()
+	}
+
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	return r.rebuiltOutcomes.HasAll(ruleNames...)
+}
+
+// maxUnavailableEnforced checks if taint additions of a rule are limited
+func (r *ReadinessGateController) maxUnavailableEnforced(rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	r.breakerMutex.Lock()
+	defer r.breakerMutex.Unlock()
+
+	return rule.Spec.MaxUnavailable != nil || r.globalMaxUnavailable != nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// ruleStatusFlushLatency measures how long a rule status change waits in
+	// memory before it is written
+	ruleStatusFlushLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "nodereadiness_rule_status_flush_latency_seconds",
+		Help:    "Time from the first queued change to a rule status until it is written.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+	})
+
+	// ruleStatusWrites counts rule status writes by outcome
+	ruleStatusWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "nodereadiness_rule_status_writes_total",
+		Help: "Rule status writes, by result (success, unchanged, conflict, error).",
+	}, []string{"result"})
+
+	// maxUnavailableBreakerOpen reports the rules whose taint additions are
+	// held back by maxUnavailable
+	maxUnavailableBreakerOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nodereadiness_rule_max_unavailable_breaker_open",
+		Help: "1 while taint additions of the rule are held back because too many nodes are gated.",
+	}, []string{"rule"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(ruleStatusFlushLatency, ruleStatusWrites, maxUnavailableBreakerOpen)
+}
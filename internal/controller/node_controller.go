@@ -18,7 +18,7 @@ package controller
 
 import (
 	"context"
-	"fmt"
+	"slices"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,8 +27,10 @@ import (
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -40,6 +42,9 @@ type NodeReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	Controller *ReadinessGateController
+
+	// MaxConcurrentReconciles is the number of nodes reconciled in parallel. Defaults to 1.
+	MaxConcurrentReconciles int
 }
 
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;create;update;patch;delete
@@ -55,26 +60,45 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	// Fetch the node
 	node := &corev1.Node{}
 	if err := r.Get(ctx, req.NamespacedName, node); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		if client.IgnoreNotFound(err) == nil {
+			// Node deleted, drop any pending grace periods
+			r.Controller.forgetNode(req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Move bootstrap completions recorded in the legacy format
+	if err := r.Controller.migrateBootstrapAnnotations(ctx, node); err != nil {
+		log.Error(err, "Failed to migrate bootstrap completion annotations", "node", node.Name)
+		return ctrl.Result{}, err
 	}
 
 	// Process node against all applicable rules
-	if err := r.Controller.processNodeAgainstAllRules(ctx, node); err != nil {
+	requeueAfter, err := r.Controller.processNodeAgainstAllRules(ctx, node)
+	if err != nil {
 		log.Error(err, "Failed to process node", "node", node.Name)
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
-// processNodeAgainstAllRules processes a single node against all applicable rules
-func (r *ReadinessGateController) processNodeAgainstAllRules(ctx context.Context, node *corev1.Node) error {
+// processNodeAgainstAllRules processes a single node against all applicable rules.
+// It returns the earliest time at which the node needs to be re-evaluated, or zero.
+func (r *ReadinessGateController) processNodeAgainstAllRules(ctx context.Context, node *corev1.Node) (time.Duration, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Get all applicable rules for this node
 	applicableRules := r.getApplicableRulesForNode(ctx, node)
 	log.Info("Processing node against rules", "node", node.Name, "ruleCount", len(applicableRules))
+	r.retainNodeOutcomes(node.Name, applicableRules)
+	r.updateRuleNodes(node.Name, applicableRules)
+	if err := r.retainRuleEvaluations(ctx, node, applicableRules); err != nil {
+		log.Error(err, "Failed to drop evaluations of rules that no longer apply", "node", node.Name)
+	}
 
+	var requeueAfter time.Duration
 	for _, rule := range applicableRules {
 		log.V(4).Info("Processing rule from cache",
 			"node", node.Name,
@@ -82,17 +106,24 @@ func (r *ReadinessGateController) processNodeAgainstAllRules(ctx context.Context
 			"resourceVersion", rule.ResourceVersion,
 			"generation", rule.Generation)
 
-		// Skip if bootstrap-only and already completed
-		if r.isBootstrapCompleted(node.Name, rule.Name) && rule.Spec.EnforcementMode == readinessv1alpha1.EnforcementModeBootstrapOnly {
-			log.Info("Skipping bootstrap-only rule - already completed",
-				"node", node.Name, "rule", rule.Name)
+		// Skip if bootstrap-only and already completed, unless the node changed
+		// in a way that re-arms the rule
+		skip, err := r.skipCompletedBootstrap(ctx, node, rule)
+		if err != nil {
+			log.Error(err, "Failed to re-arm bootstrap-only rule", "node", node.Name, "rule", rule.Name)
+			r.recordNodeFailure(rule, node.Name, "RearmError", err.Error())
+			continue
+		}
+		if skip {
 			continue
 		}
 
-		// Skip if dry run or global dry run
-		if rule.Spec.DryRun || r.globalDryRun {
-			log.Info("Skipping rule - dry run mode",
+		// Only update the dry run plan if dry run or global dry run
+		if rule.Spec.DryRun || r.isGlobalDryRun() {
+			log.Info("Evaluating rule for node in dry run mode",
 				"node", node.Name, "rule", rule.Name)
+			r.evaluateDryRunForNode(rule, node)
+			r.queueRuleStatus(rule)
 			continue
 		}
 
@@ -101,126 +132,282 @@ func (r *ReadinessGateController) processNodeAgainstAllRules(ctx context.Context
 			"rule", rule.Name,
 			"ruleResourceVersion", rule.ResourceVersion)
 
-		if err := r.evaluateRuleForNode(ctx, rule, node); err != nil {
+		ruleRequeue, err := r.evaluateRuleForNode(ctx, rule, node)
+		if err != nil {
 			log.Error(err, "Failed to evaluate rule for node",
 				"node", node.Name, "rule", rule.Name)
 			// Continue with other rules even if one fails
 			r.recordNodeFailure(rule, node.Name, "EvaluationError", err.Error())
+		} else {
+			r.clearNodeFailure(rule, node.Name)
 		}
+		requeueAfter = minRequeue(requeueAfter, ruleRequeue)
+		r.refreshTaintBreaker(ctx, rule)
 
-		// Persist the rule status
-		log.V(4).Info("Attempting to persist rule status",
-			"node", node.Name,
-			"rule", rule.Name,
-			"resourceVersion", rule.ResourceVersion)
-
-		if err := r.updateRuleStatus(ctx, rule); err != nil {
-			log.Error(err, "Failed to update rule status after node evaluation",
-				"node", node.Name,
-				"rule", rule.Name,
-				"resourceVersion", rule.ResourceVersion)
-			// continue with other rules
-		} else {
-			log.V(4).Info("Successfully persisted rule status from node reconciler",
-				"node", node.Name,
-				"rule", rule.Name,
-				"newResourceVersion", rule.ResourceVersion)
+		// Nodes the canary has not reached preview the taints they would get
+		if !r.canaryReached(rule, node) {
+			r.evaluateDryRunForNode(rule, node)
 		}
+
+		// Queue the rule status; node events are written in batches
+		r.queueRuleStatus(rule)
 	}
 
-	return nil
+	return requeueAfter, nil
 }
 
 // getConditionStatus gets the status of a condition on a node
 func (r *ReadinessGateController) getConditionStatus(node *corev1.Node, conditionType string) corev1.ConditionStatus {
-	for _, condition := range node.Status.Conditions {
-		if string(condition.Type) == conditionType {
-			return condition.Status
-		}
+	if condition := r.getCondition(node, conditionType); condition != nil {
+		return condition.Status
 	}
 	return corev1.ConditionUnknown
 }
 
-// hasTaintBySpec checks if a node has a specific taint
+// getCondition returns a condition from a node, or nil if the node does not report it
+func (r *ReadinessGateController) getCondition(node *corev1.Node, conditionType string) *corev1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if string(node.Status.Conditions[i].Type) == conditionType {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// hasTaintBySpec checks if a node has a specific taint. Only key and effect
+// are compared, so a taint with an outdated value still counts.
 func (r *ReadinessGateController) hasTaintBySpec(node *corev1.Node, taintSpec readinessv1alpha1.TaintSpec) bool {
 	for _, taint := range node.Spec.Taints {
-		if taint.Key == taintSpec.Key && taint.Effect == taintSpec.Effect {
+		if taintMatchesSpec(taint, taintSpec) {
 			return true
 		}
 	}
 	return false
 }
 
-// addTaintBySpec adds a taint to a node
-func (r *ReadinessGateController) addTaintBySpec(ctx context.Context, node *corev1.Node, taintSpec readinessv1alpha1.TaintSpec) error {
-	patch := client.StrategicMergeFrom(node.DeepCopy())
-	node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
-		Key:    taintSpec.Key,
-		Value:  taintSpec.Value,
-		Effect: taintSpec.Effect,
+// hasAnyTaintBySpec checks if a node has at least one of the given taints
+// owned by the rule
+func (r *ReadinessGateController) hasAnyTaintBySpec(
+	node *corev1.Node,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	taintSpecs []readinessv1alpha1.TaintSpec,
+) bool {
+	for _, taintSpec := range taintSpecs {
+		if r.hasTaintBySpec(node, taintSpec) && ownsTaint(node, rule, taintSpec) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAllTaintsBySpec checks if a node has every one of the given taints.
+// Taints owned by the rule must also have the expected value.
+func (r *ReadinessGateController) hasAllTaintsBySpec(
+	node *corev1.Node,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	taintSpecs []readinessv1alpha1.TaintSpec,
+) bool {
+	for _, taintSpec := range taintSpecs {
+		found := false
+		for _, taint := range node.Spec.Taints {
+			if taintMatchesSpec(taint, taintSpec) {
+				found = taint.Value == taintSpec.Value || !ownsTaint(node, rule, taintSpec)
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// patchNode applies mutate to the node and writes the result in a single
+// patch if it changed anything
+func (r *ReadinessGateController) patchNode(ctx context.Context, node *corev1.Node, mutate func(node *corev1.Node) bool) error {
+	return r.patchNodeWith(ctx, node, mutate, func(patch client.Patch) error {
+		return r.Patch(ctx, node, patch)
+	})
+}
+
+// patchNodeStatus applies mutate to the node status and writes the result in
+// a single patch if it changed anything
+func (r *ReadinessGateController) patchNodeStatus(ctx context.Context, node *corev1.Node, mutate func(node *corev1.Node) bool) error {
+	return r.patchNodeWith(ctx, node, mutate, func(patch client.Patch) error {
+		return r.Status().Patch(ctx, node, patch)
+	})
+}
+
+// patchNodeWith writes a node patch guarded by the node's resourceVersion,
+// so that rules processed concurrently cannot overwrite each other's taints
+// or ownership records. On a conflict the node is read again and mutate is
+// re-applied to the latest version.
+func (r *ReadinessGateController) patchNodeWith(
+	ctx context.Context,
+	node *corev1.Node,
+	mutate func(node *corev1.Node) bool,
+	write func(patch client.Patch) error,
+) error {
+	refresh := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refresh {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(node), node); err != nil {
+				return err
+			}
+		}
+		refresh = true
+
+		patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if !mutate(node) {
+			return nil
+		}
+		return write(patch)
 	})
-	return r.Patch(ctx, node, patch)
 }
 
-// removeTaintBySpec removes a taint from a node
-func (r *ReadinessGateController) removeTaintBySpec(ctx context.Context, node *corev1.Node, taintSpec readinessv1alpha1.TaintSpec) error {
-	patch := client.StrategicMergeFrom(node.DeepCopy())
+// addTaintsBySpec adds the given taints and records the rule as their owner.
+// Owned taints are updated in place if their value drifted from the spec;
+// taints added by someone else are left alone.
+func (r *ReadinessGateController) addTaintsBySpec(
+	node *corev1.Node,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	taintSpecs []readinessv1alpha1.TaintSpec,
+) {
+	var owned []readinessv1alpha1.TaintSpec
+	for _, taintSpec := range taintSpecs {
+		if !r.hasTaintBySpec(node, taintSpec) {
+			node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+				Key:    taintSpec.Key,
+				Value:  taintSpec.Value,
+				Effect: taintSpec.Effect,
+			})
+			owned = append(owned, taintSpec)
+			continue
+		}
+		if !ownsTaint(node, rule, taintSpec) {
+			continue
+		}
+		for i := range node.Spec.Taints {
+			if taintMatchesSpec(node.Spec.Taints[i], taintSpec) {
+				node.Spec.Taints[i].Value = taintSpec.Value
+			}
+		}
+		owned = append(owned, taintSpec)
+	}
+	recordOwnedTaints(node, rule, owned)
+}
+
+// removeTaintsBySpec removes the given taints owned by the rule from a node,
+// along with their ownership records
+func (r *ReadinessGateController) removeTaintsBySpec(
+	node *corev1.Node,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	taintSpecs []readinessv1alpha1.TaintSpec,
+) {
+	var owned []readinessv1alpha1.TaintSpec
+	for _, taintSpec := range taintSpecs {
+		if ownsTaint(node, rule, taintSpec) {
+			owned = append(owned, taintSpec)
+		}
+	}
+
 	var newTaints []corev1.Taint
 	for _, taint := range node.Spec.Taints {
-		if !(taint.Key == taintSpec.Key && taint.Effect == taintSpec.Effect) {
+		if !taintSpecsContain(owned, taint) {
 			newTaints = append(newTaints, taint)
 		}
 	}
 	node.Spec.Taints = newTaints
-	return r.Patch(ctx, node, patch)
+	forgetOwnedTaints(node, rule, taintSpecs)
 }
 
-// Bootstrap completion tracking
-func (r *ReadinessGateController) isBootstrapCompleted(nodeName, ruleName string) bool {
-	// Check node annotation
-	node := &corev1.Node{}
-	if err := r.Get(context.TODO(), client.ObjectKey{Name: nodeName}, node); err != nil {
-		return false
-	}
-
-	annotationKey := fmt.Sprintf("readiness.k8s.io/bootstrap-completed-%s", ruleName)
-	_, exists := node.Annotations[annotationKey]
-	return exists
+// replaceTaintsBySpec swaps taints of a previous rule spec for their
+// replacements, or removes them if they have none, in a single patch. Only
+// taints owned by the rule are touched.
+func (r *ReadinessGateController) replaceTaintsBySpec(
+	ctx context.Context,
+	node *corev1.Node,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	migrations []taintMigration,
+) error {
+	return r.patchNode(ctx, node, func(node *corev1.Node) bool {
+		return r.migrateTaints(node, rule, migrations)
+	})
 }
 
-func (r *ReadinessGateController) markBootstrapCompleted(ctx context.Context, nodeName, ruleName string) {
-	log := ctrl.LoggerFrom(ctx)
-
-	annotationKey := fmt.Sprintf("readiness.k8s.io/bootstrap-completed-%s", ruleName)
-
-	// retry to handle conflict with concurrent node updates
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		node := &corev1.Node{}
-		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
-			return err
+// migrateTaints applies taint migrations to a node, returning false if none
+// of them applies
+func (r *ReadinessGateController) migrateTaints(
+	node *corev1.Node,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	migrations []taintMigration,
+) bool {
+	var applicable []taintMigration
+	for _, migration := range migrations {
+		if !ownsTaint(node, rule, migration.from) {
+			continue
 		}
-
-		// Check if already marked to avoid unnecessary updates
-		if node.Annotations != nil {
-			if _, exists := node.Annotations[annotationKey]; exists {
-				return nil
+		for _, taint := range node.Spec.Taints {
+			if !taintMatchesSpec(taint, migration.from) {
+				continue
+			}
+			if migration.to == nil || !taintMatchesSpec(taint, *migration.to) || taint.Value != migration.to.Value {
+				applicable = append(applicable, migration)
+				break
 			}
 		}
+	}
+	if len(applicable) == 0 {
+		return false
+	}
 
-		// Initialize annotations if nil
-		if node.Annotations == nil {
-			node.Annotations = make(map[string]string)
+	var newTaints []corev1.Taint
+	for _, taint := range node.Spec.Taints {
+		replaced := false
+		for _, migration := range applicable {
+			if taintMatchesSpec(taint, migration.from) ||
+				(migration.to != nil && taintMatchesSpec(taint, *migration.to)) {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			newTaints = append(newTaints, taint)
 		}
+	}
 
-		node.Annotations[annotationKey] = "true"
-		return r.Update(ctx, node)
-	})
+	var removed, added []readinessv1alpha1.TaintSpec
+	for _, migration := range applicable {
+		removed = append(removed, migration.from)
+		if migration.to == nil {
+			continue
+		}
+		added = append(added, *migration.to)
+		newTaints = append(newTaints, corev1.Taint{
+			Key:    migration.to.Key,
+			Value:  migration.to.Value,
+			Effect: migration.to.Effect,
+		})
+	}
+	node.Spec.Taints = newTaints
+	forgetOwnedTaints(node, rule, removed)
+	recordOwnedTaints(node, rule, added)
+	return true
+}
 
-	if err != nil {
-		log.Error(err, "Failed to mark bootstrap completed", "node", nodeName, "rule", ruleName)
-	} else {
-		log.Info("Marked bootstrap completed", "node", nodeName, "rule", ruleName)
+// taintSpecsContain checks if a node taint matches one of the given taint specs
+func taintSpecsContain(taintSpecs []readinessv1alpha1.TaintSpec, taint corev1.Taint) bool {
+	for _, taintSpec := range taintSpecs {
+		if taintMatchesSpec(taint, taintSpec) {
+			return true
+		}
 	}
+	return false
+}
+
+// taintMatchesSpec checks if a node taint has the key and effect of a taint spec
+func taintMatchesSpec(taint corev1.Taint, taintSpec readinessv1alpha1.TaintSpec) bool {
+	return taint.Key == taintSpec.Key && taint.Effect == taintSpec.Effect
 }
 
 // recordNodeFailure records a failure for a specific node
@@ -228,23 +415,54 @@ func (r *ReadinessGateController) recordNodeFailure(
 	rule *readinessv1alpha1.NodeReadinessGateRule,
 	nodeName, reason, message string,
 ) {
+	r.markNodeOutcomeFailed(rule.Name, nodeName)
+
+	r.failingNodesMutex.Lock()
+	defer r.failingNodesMutex.Unlock()
+
 	// Remove any existing failure for this node
-	var failedNodes []readinessv1alpha1.NodeFailure
-	for _, failure := range rule.Status.FailedNodes {
-		if failure.NodeName != nodeName {
-			failedNodes = append(failedNodes, failure)
-		}
-	}
+	removeNodeFailure(rule, nodeName)
 
-	// Add new failure
-	failedNodes = append(failedNodes, readinessv1alpha1.NodeFailure{
+	// Add new failure, keeping only the most recent ones
+	failingNodes := append(rule.Status.FailingNodes, readinessv1alpha1.NodeFailure{
 		NodeName:    nodeName,
 		Reason:      reason,
 		Message:     message,
 		LastUpdated: metav1.Now(),
 	})
+	if len(failingNodes) > maxFailingNodes {
+		failingNodes = failingNodes[len(failingNodes)-maxFailingNodes:]
+	}
+
+	rule.Status.FailingNodes = failingNodes
+}
 
-	rule.Status.FailedNodes = failedNodes
+// clearNodeFailure removes a node from the failing node sample of a rule
+func (r *ReadinessGateController) clearNodeFailure(rule *readinessv1alpha1.NodeReadinessGateRule, nodeName string) {
+	r.failingNodesMutex.Lock()
+	defer r.failingNodesMutex.Unlock()
+
+	removeNodeFailure(rule, nodeName)
+}
+
+// failingNodesSnapshot returns a copy of the failing node sample of a rule
+func (r *ReadinessGateController) failingNodesSnapshot(rule *readinessv1alpha1.NodeReadinessGateRule) []readinessv1alpha1.NodeFailure {
+	r.failingNodesMutex.Lock()
+	defer r.failingNodesMutex.Unlock()
+
+	return slices.Clone(rule.Status.FailingNodes)
+}
+
+// removeNodeFailure drops a node from the failing node sample. The caller
+// must hold failingNodesMutex.
+func removeNodeFailure(rule *readinessv1alpha1.NodeReadinessGateRule, nodeName string) {
+	var failingNodes []readinessv1alpha1.NodeFailure
+	for _, failure := range rule.Status.FailingNodes {
+		if failure.NodeName != nodeName {
+			failingNodes = append(failingNodes, failure)
+		}
+	}
+	rule.Status.FailingNodes = failingNodes
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -252,10 +470,21 @@ func (r *NodeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager)
 	nodeController, err := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Node{}).
 		Named("node").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Build(r)
 	if err != nil {
 		return err
 	}
+	// Index nodes by label so that rules list only the nodes they can match
+	if err := r.Controller.setupNodeIndex(ctx, mgr); err != nil {
+		return err
+	}
+
+	// Write the rule status changes queued by node events
+	if err := mgr.Add(manager.RunnableFunc(r.Controller.runStatusFlusher)); err != nil {
+		return err
+	}
+
 	// Watch node create/update events for readiness taint processing
 	return nodeController.Watch(
 		source.Kind(mgr.GetCache(), &corev1.Node{},
@@ -275,15 +504,18 @@ func (r *NodeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager)
 					conditionsChanged := !conditionsEqual(oldNode.Status.Conditions, newNode.Status.Conditions)
 					taintsChanged := !taintsEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
 					labelsChanged := !labelsEqual(oldNode.Labels, newNode.Labels)
+					nodeInfoChanged := oldNode.Status.NodeInfo.BootID != newNode.Status.NodeInfo.BootID ||
+						oldNode.Status.NodeInfo.KubeletVersion != newNode.Status.NodeInfo.KubeletVersion
 
-					shouldReconcile := conditionsChanged || taintsChanged || labelsChanged
+					shouldReconcile := conditionsChanged || taintsChanged || labelsChanged || nodeInfoChanged
 
 					if shouldReconcile {
 						log.V(4).Info("NodeReconciler processing node update event",
 							"node", newNode.Name,
 							"conditionsChanged", conditionsChanged,
 							"taintsChanged", taintsChanged,
-							"labelsChanged", labelsChanged)
+							"labelsChanged", labelsChanged,
+							"nodeInfoChanged", nodeInfoChanged)
 					}
 
 					return shouldReconcile
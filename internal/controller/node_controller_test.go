@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -25,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -95,6 +97,17 @@ var _ = Describe("Node Controller", func() {
 			Expect(labelsEqual(labels1, labels3)).To(BeFalse(), "different value should not be equal")
 			Expect(labelsEqual(labels1, labels4)).To(BeFalse(), "different length should not be equal")
 		})
+
+		It("should keep cordon and label annotation keys valid for long rule names", func() {
+			Expect(cordonAnnotationKey("short-rule")).To(Equal("readiness.k8s.io/cordoned-by-short-rule"))
+
+			key := cordonAnnotationKey(strings.Repeat("long-rule.", 25))
+			Expect(validation.IsQualifiedName(key)).To(BeEmpty())
+			Expect(key).NotTo(Equal(cordonAnnotationKey(strings.Repeat("long-rule.", 24))))
+
+			Expect(labelAnnotationKey("short-rule")).To(Equal("readiness.k8s.io/labeled-by-short-rule"))
+			Expect(validation.IsQualifiedName(labelAnnotationKey(strings.Repeat("long-rule.", 25)))).To(BeEmpty())
+		})
 	})
 
 	// Reconciliation tests need cluster resources
@@ -152,13 +165,15 @@ var _ = Describe("Node Controller", func() {
 					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
 						{Type: conditionType, RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: nodereadinessiov1alpha1.TaintSpec{
+					Taint: &nodereadinessiov1alpha1.TaintSpec{
 						Key:    taintKey,
 						Effect: corev1.TaintEffectNoSchedule,
 					},
 					NodeSelector: &metav1.LabelSelector{
 						MatchLabels: map[string]string{"env": "test"},
 					},
+					// The node registers with the taint
+					AdoptExisting: true,
 				},
 			}
 		})
@@ -221,12 +236,13 @@ var _ = Describe("Node Controller", func() {
 					return false
 				}, time.Second*5).Should(BeFalse())
 
-				// Verify bootstrap completion annotation is added
-				Eventually(func() map[string]string {
+				// Verify bootstrap completion is recorded
+				Eventually(func() bool {
 					updatedNode := &corev1.Node{}
 					_ = k8sClient.Get(ctx, namespacedName, updatedNode)
-					return updatedNode.Annotations
-				}).Should(HaveKey("readiness.k8s.io/bootstrap-completed-" + ruleName))
+					_, completed := getBootstrapCompletion(updatedNode, rule)
+					return completed
+				}).Should(BeTrue())
 			})
 
 			It("should not re-add the taint if conditions regress after completion", func() {
@@ -268,6 +284,27 @@ var _ = Describe("Node Controller", func() {
 					return false
 				}, time.Second*2).Should(BeFalse())
 			})
+
+			It("should leave completed nodes alone when the rule is requeued", func() {
+				node.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(isBootstrapCompleted(updatedNode, rule)).To(BeTrue())
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionFalse
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				// A requeued rule reconcile evaluates every matching node
+				_, err = readinessController.processAllNodesForRule(ctx, rule)
+				Expect(err).NotTo(HaveOccurred())
+
+				recheckedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, recheckedNode)).To(Succeed())
+				Expect(recheckedNode.Spec.Taints).NotTo(ContainElement(HaveField("Key", taintKey)))
+			})
 		})
 
 		When("in continuous mode", func() {
@@ -294,6 +331,21 @@ var _ = Describe("Node Controller", func() {
 				}, time.Second*5).Should(BeFalse())
 			})
 
+			It("should drop the evaluation once the rule no longer applies", func() {
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchRuleEvaluation(ctx, nodeName, ruleName)).NotTo(BeNil())
+
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Labels["env"] = "other"
+				Expect(k8sClient.Update(ctx, updatedNode)).To(Succeed())
+
+				_, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchRuleEvaluation(ctx, nodeName, ruleName)).To(BeNil())
+			})
+
 			It("should re-add the taint if conditions regress", func() {
 				// Step 1: Meet conditions and remove taint
 				node.Status.Conditions[0].Status = corev1.ConditionTrue
@@ -335,6 +387,422 @@ var _ = Describe("Node Controller", func() {
 			})
 		})
 
+		When("a grace period is set in continuous mode", func() {
+			BeforeEach(func() {
+				rule.Spec.EnforcementMode = nodereadinessiov1alpha1.EnforcementModeContinuous
+				rule.Spec.GracePeriod = &metav1.Duration{Duration: 2 * time.Second}
+				node.Spec.Taints = nil
+			})
+
+			// regress lets the node satisfy the rule once and then fail it,
+			// since the grace period only applies to nodes that were healthy
+			regress := func() {
+				node.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, namespacedName, node)).To(Succeed())
+				node.Status.Conditions[0].Status = corev1.ConditionFalse
+				Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+			}
+
+			It("should taint a node that never satisfied the rule immediately", func() {
+				result, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Spec.Taints).To(ContainElement(HaveField("Key", taintKey)))
+			})
+
+			It("should defer the taint until the grace period expires", func() {
+				regress()
+				result, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", 2*time.Second))
+
+				// Taint is pending, not applied
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				for _, taint := range updatedNode.Spec.Taints {
+					Expect(taint.Key).NotTo(Equal(taintKey))
+				}
+
+				evaluation := fetchRuleEvaluation(ctx, nodeName, ruleName)
+				Expect(evaluation).NotTo(BeNil())
+				Expect(evaluation.TaintStatus).To(Equal("Pending"))
+				Expect(evaluation.PendingTaintUntil).NotTo(BeNil())
+
+				// Reconcile again once the grace period has expired
+				time.Sleep(result.RequeueAfter)
+				result, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				Eventually(func() bool {
+					recheckedNode := &corev1.Node{}
+					_ = k8sClient.Get(ctx, namespacedName, recheckedNode)
+					for _, taint := range recheckedNode.Spec.Taints {
+						if taint.Key == taintKey {
+							return true
+						}
+					}
+					return false
+				}, time.Second*5).Should(BeTrue())
+			})
+
+			When("the controller restarts during the grace period", func() {
+				BeforeEach(func() {
+					rule.Spec.GracePeriod = &metav1.Duration{Duration: time.Hour}
+				})
+
+				It("should keep the grace deadline", func() {
+					regress()
+					_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+					Expect(err).NotTo(HaveOccurred())
+					evaluation := fetchRuleEvaluation(ctx, nodeName, ruleName)
+					Expect(evaluation).NotTo(BeNil())
+					Expect(evaluation.PendingTaintUntil).NotTo(BeNil())
+					deadline := evaluation.PendingTaintUntil.Time
+
+					// A restarted controller only has the NodeReadinessStatus
+					time.Sleep(1100 * time.Millisecond)
+					readinessController.forgetNode(nodeName)
+
+					result, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeNumerically("<=", time.Until(deadline)+time.Second))
+
+					evaluation = fetchRuleEvaluation(ctx, nodeName, ruleName)
+					Expect(evaluation).NotTo(BeNil())
+					Expect(evaluation.PendingTaintUntil).NotTo(BeNil())
+					Expect(evaluation.PendingTaintUntil.Time).To(BeTemporally("==", deadline))
+				})
+			})
+
+			It("should not taint the node if conditions recover within the grace period", func() {
+				regress()
+				result, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+				// Condition recovers before the deadline
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				result, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				// Failure tracking is reset
+				readinessController.failingSinceMutex.Lock()
+				_, tracked := readinessController.failingSince[ruleName][nodeName]
+				readinessController.failingSinceMutex.Unlock()
+				Expect(tracked).To(BeFalse())
+
+				Consistently(func() bool {
+					recheckedNode := &corev1.Node{}
+					_ = k8sClient.Get(ctx, namespacedName, recheckedNode)
+					for _, taint := range recheckedNode.Spec.Taints {
+						if taint.Key == taintKey {
+							return true
+						}
+					}
+					return false
+				}, time.Second*3).Should(BeFalse())
+			})
+		})
+
+		When("stableFor is set in continuous mode", func() {
+			BeforeEach(func() {
+				rule.Spec.EnforcementMode = nodereadinessiov1alpha1.EnforcementModeContinuous
+				rule.Spec.StableFor = &metav1.Duration{Duration: 2 * time.Second}
+			})
+
+			It("should keep the taint until conditions have been stable", func() {
+				node.Status.Conditions[0].Status = corev1.ConditionTrue
+				node.Status.Conditions[0].LastTransitionTime = metav1.Now()
+				Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+				result, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", 2*time.Second))
+
+				// Taint is still present and removal is scheduled
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Spec.Taints).To(ContainElement(HaveField("Key", taintKey)))
+
+				evaluation := fetchRuleEvaluation(ctx, nodeName, ruleName)
+				Expect(evaluation).NotTo(BeNil())
+				Expect(evaluation.TaintStatus).To(Equal("Present"))
+				Expect(evaluation.PendingRemovalUntil).NotTo(BeNil())
+
+				// Reconcile again once the window has passed
+				time.Sleep(result.RequeueAfter)
+				result, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				Eventually(func() bool {
+					recheckedNode := &corev1.Node{}
+					_ = k8sClient.Get(ctx, namespacedName, recheckedNode)
+					for _, taint := range recheckedNode.Spec.Taints {
+						if taint.Key == taintKey {
+							return true
+						}
+					}
+					return false
+				}, time.Second*5).Should(BeFalse())
+			})
+		})
+
+		When("a rule manages multiple taints", func() {
+			BeforeEach(func() {
+				rule.Spec.EnforcementMode = nodereadinessiov1alpha1.EnforcementModeContinuous
+				rule.Spec.Taint = nil
+				rule.Spec.Taints = []nodereadinessiov1alpha1.TaintSpec{
+					{Key: taintKey, Effect: corev1.TaintEffectNoSchedule},
+					{Key: taintKey, Effect: corev1.TaintEffectNoExecute},
+				}
+			})
+
+			It("should add and remove all taints together", func() {
+				hasRuleTaints := func() []corev1.TaintEffect {
+					updatedNode := &corev1.Node{}
+					_ = k8sClient.Get(ctx, namespacedName, updatedNode)
+					var effects []corev1.TaintEffect
+					for _, taint := range updatedNode.Spec.Taints {
+						if taint.Key == taintKey {
+							effects = append(effects, taint.Effect)
+						}
+					}
+					return effects
+				}
+
+				// The node only has the NoSchedule taint; the missing one is added
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(hasRuleTaints, time.Second*5).Should(ConsistOf(
+					corev1.TaintEffectNoSchedule,
+					corev1.TaintEffectNoExecute,
+				))
+
+				// Conditions are met; both taints are removed
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				_, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(hasRuleTaints, time.Second*5).Should(BeEmpty())
+			})
+		})
+
+		When("a rule uses gate actions instead of taints", func() {
+			var conditionName corev1.NodeConditionType
+
+			BeforeEach(func() {
+				rule.Spec.EnforcementMode = nodereadinessiov1alpha1.EnforcementModeContinuous
+				rule.Spec.Taint = nil
+				rule.Spec.Actions = &nodereadinessiov1alpha1.GateActions{
+					Cordon:    true,
+					Label:     &nodereadinessiov1alpha1.LabelAction{Key: "readiness.k8s.io/gated", Value: "true"},
+					Condition: &nodereadinessiov1alpha1.ConditionAction{},
+				}
+				conditionName = corev1.NodeConditionType("ReadinessGates/" + ruleName)
+			})
+
+			getGateCondition := func(node *corev1.Node) corev1.ConditionStatus {
+				for _, condition := range node.Status.Conditions {
+					if condition.Type == conditionName {
+						return condition.Status
+					}
+				}
+				return ""
+			}
+
+			It("should apply and revert every action", func() {
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+				Expect(updatedNode.Labels).To(HaveKeyWithValue("readiness.k8s.io/gated", "true"))
+				Expect(updatedNode.Annotations).To(HaveKey(labelAnnotationKey(ruleName)))
+				Expect(getGateCondition(updatedNode)).To(Equal(corev1.ConditionFalse))
+
+				// Conditions are met; every action is reverted
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				_, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Spec.Unschedulable).To(BeFalse())
+				Expect(updatedNode.Labels).NotTo(HaveKey("readiness.k8s.io/gated"))
+				Expect(updatedNode.Annotations).NotTo(HaveKey(labelAnnotationKey(ruleName)))
+				Expect(getGateCondition(updatedNode)).To(Equal(corev1.ConditionTrue))
+
+				Expect(fetchRuleEvaluation(ctx, nodeName, ruleName).TaintStatus).To(Equal("Absent"))
+			})
+
+			It("should not lift a cordon it did not set", func() {
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Spec.Unschedulable = true
+				Expect(k8sClient.Update(ctx, updatedNode)).To(Succeed())
+
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				_, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+				Expect(getGateCondition(updatedNode)).To(Equal(corev1.ConditionTrue))
+			})
+
+			It("should not remove a label it did not set", func() {
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Labels["readiness.k8s.io/gated"] = "true"
+				Expect(k8sClient.Update(ctx, updatedNode)).To(Succeed())
+
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Annotations).NotTo(HaveKey(labelAnnotationKey(ruleName)))
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				_, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Labels).To(HaveKeyWithValue("readiness.k8s.io/gated", "true"))
+				Expect(getGateCondition(updatedNode)).To(Equal(corev1.ConditionTrue))
+			})
+		})
+
+		When("the rule does not adopt existing taints", func() {
+			BeforeEach(func() {
+				rule.Spec.EnforcementMode = nodereadinessiov1alpha1.EnforcementModeContinuous
+				rule.Spec.AdoptExisting = false
+			})
+
+			hasRuleTaint := func() bool {
+				updatedNode := &corev1.Node{}
+				_ = k8sClient.Get(ctx, namespacedName, updatedNode)
+				for _, taint := range updatedNode.Spec.Taints {
+					if taint.Key == taintKey {
+						return true
+					}
+				}
+				return false
+			}
+
+			It("should leave a taint it did not add", func() {
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Consistently(hasRuleTaint, time.Second*2).Should(BeTrue())
+
+				// Nor is it removed when the rule is deleted
+				Expect(readinessController.cleanupTaintsForRule(ctx, rule)).To(Succeed())
+				Expect(hasRuleTaint()).To(BeTrue())
+			})
+
+			It("should record and remove the taints it added", func() {
+				updatedNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				updatedNode.Spec.Taints = nil
+				Expect(k8sClient.Update(ctx, updatedNode)).To(Succeed())
+
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasRuleTaint()).To(BeTrue())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Annotations).To(HaveKey(managedTaintsAnnotation))
+				Expect(getManagedTaints(updatedNode)).To(HaveKeyWithValue(ruleName, HaveField("Taints", ConsistOf(
+					taintKey+":"+string(corev1.TaintEffectNoSchedule),
+				))))
+
+				updatedNode.Status.Conditions[0].Status = corev1.ConditionTrue
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				_, err = nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasRuleTaint()).To(BeFalse())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(updatedNode.Annotations).NotTo(HaveKey(managedTaintsAnnotation))
+			})
+
+			It("should not lose ownership records written concurrently", func() {
+				staleNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, staleNode)).To(Succeed())
+
+				// Another rule records its taint after the node was read
+				otherRule := rule.DeepCopy()
+				otherRule.Name = "other-rule"
+				updatedNode := staleNode.DeepCopy()
+				recordOwnedTaints(updatedNode, otherRule, otherRule.Spec.GetTaints())
+				Expect(k8sClient.Update(ctx, updatedNode)).To(Succeed())
+
+				Expect(readinessController.patchNode(ctx, staleNode, func(node *corev1.Node) bool {
+					recordOwnedTaints(node, rule, rule.Spec.GetTaints())
+					return true
+				})).To(Succeed())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(getManagedTaints(updatedNode)).To(HaveKey(ruleName))
+				Expect(getManagedTaints(updatedNode)).To(HaveKey("other-rule"))
+			})
+
+			It("should not lose node conditions written concurrently", func() {
+				staleNode := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, namespacedName, staleNode)).To(Succeed())
+
+				// An agent reports a condition after the node was read
+				updatedNode := staleNode.DeepCopy()
+				updatedNode.Status.Conditions = append(updatedNode.Status.Conditions, corev1.NodeCondition{
+					Type:   "AgentReady",
+					Status: corev1.ConditionTrue,
+				})
+				Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+
+				Expect(readinessController.setConditionBySpec(ctx, staleNode, "GateApplied",
+					corev1.ConditionTrue, "Gated", "test")).To(Succeed())
+
+				Expect(k8sClient.Get(ctx, namespacedName, updatedNode)).To(Succeed())
+				Expect(readinessController.hasConditionBySpec(updatedNode, "AgentReady", corev1.ConditionTrue)).To(BeTrue())
+				Expect(readinessController.hasConditionBySpec(updatedNode, "GateApplied", corev1.ConditionTrue)).To(BeTrue())
+			})
+
+		})
+
 		When("a rule's node selector does not match", func() {
 			BeforeEach(func() {
 				rule.Spec.NodeSelector.MatchLabels = map[string]string{"env": "non-existent"}
@@ -356,3 +824,12 @@ var _ = Describe("Node Controller", func() {
 		})
 	})
 })
+
+// fetchRuleEvaluation returns the evaluation of a rule reported in a node's NodeReadinessStatus
+func fetchRuleEvaluation(ctx context.Context, nodeName, ruleName string) *nodereadinessiov1alpha1.RuleEvaluation {
+	status := &nodereadinessiov1alpha1.NodeReadinessStatus{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, status); err != nil {
+		return nil
+	}
+	return getRuleEvaluation(status, ruleName)
+}
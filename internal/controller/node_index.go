@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// nodeLabelIndex indexes nodes in the manager cache by each of their label
+// keys and key=value pairs
+const nodeLabelIndex = "nodereadiness.io/label"
+
+// nodeLabelIndexValues returns the values a node is indexed under
+func nodeLabelIndexValues(obj client.Object) []string {
+	nodeLabels := obj.GetLabels()
+	values := make([]string, 0, 2*len(nodeLabels))
+	for key, value := range nodeLabels {
+		values = append(values, key, key+"="+value)
+	}
+	return values
+}
+
+// setupNodeIndex registers the node label index with the manager cache, so
+// that rule reconciles list only the nodes their selector can match
+func (r *ReadinessGateController) setupNodeIndex(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &corev1.Node{}, nodeLabelIndex, nodeLabelIndexValues); err != nil {
+		return fmt.Errorf("failed to index nodes by label: %w", err)
+	}
+	r.nodeLabelIndexed = true
+	return nil
+}
+
+// selectorIndexValue picks a requirement of the selector that the node label
+// index can answer. Every node matching the selector is indexed under it.
+func selectorIndexValue(selector labels.Selector) (string, bool) {
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return "", false
+	}
+
+	for _, requirement := range requirements {
+		values := requirement.ValuesUnsorted()
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals:
+			return requirement.Key() + "=" + values[0], true
+		case selection.In:
+			if len(values) == 1 {
+				return requirement.Key() + "=" + values[0], true
+			}
+		case selection.Exists:
+			return requirement.Key(), true
+		}
+	}
+	return "", false
+}
+
+// listNodesMatching lists the nodes matching a label selector, using the node
+// label index when it can narrow the list down
+func (r *ReadinessGateController) listNodesMatching(ctx context.Context, selector labels.Selector) ([]corev1.Node, error) {
+	var opts []client.ListOption
+	if value, ok := selectorIndexValue(selector); ok && r.nodeLabelIndexed {
+		opts = append(opts, client.MatchingFields{nodeLabelIndex: value})
+	} else if !selector.Empty() {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	// The index only answers one requirement of the selector
+	nodes := nodeList.Items[:0]
+	for _, node := range nodeList.Items {
+		if selector.Matches(labels.Set(node.Labels)) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// listRuleNodes lists the nodes a rule currently applies to
+func (r *ReadinessGateController) listRuleNodes(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) ([]corev1.Node, error) {
+	selector, err := r.ruleSelector(rule)
+	if err != nil {
+		return nil, err
+	}
+	return r.listNodesMatching(ctx, selector)
+}
+
+// previousRuleNodes returns the nodes a cached rule applied to when it was
+// last processed, falling back to its selector if they are not known
+func (r *ReadinessGateController) previousRuleNodes(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) ([]corev1.Node, error) {
+	nodeNames, known := r.knownRuleNodes(rule.Name)
+	if !known {
+		return r.listRuleNodes(ctx, rule)
+	}
+
+	nodes := make([]corev1.Node, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		node := corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// setRuleNodes records the full set of nodes a rule applies to
+func (r *ReadinessGateController) setRuleNodes(ruleName string, nodes []corev1.Node) {
+	nodeNames := sets.New[string]()
+	for _, node := range nodes {
+		nodeNames.Insert(node.Name)
+	}
+
+	r.ruleNodesMutex.Lock()
+	defer r.ruleNodesMutex.Unlock()
+
+	if r.ruleNodes == nil {
+		r.ruleNodes = make(map[string]sets.Set[string])
+	}
+	r.ruleNodes[ruleName] = nodeNames
+}
+
+// knownRuleNodes returns the nodes a rule applies to, if they are known
+func (r *ReadinessGateController) knownRuleNodes(ruleName string) ([]string, bool) {
+	r.ruleNodesMutex.Lock()
+	defer r.ruleNodesMutex.Unlock()
+
+	nodeNames, known := r.ruleNodes[ruleName]
+	if !known {
+		return nil, false
+	}
+	return sets.List(nodeNames), true
+}
+
+// updateRuleNodes moves a node in or out of the known node sets of the rules
+// after its labels changed
+func (r *ReadinessGateController) updateRuleNodes(nodeName string, applicableRules []*readinessv1alpha1.NodeReadinessGateRule) {
+	applicable := make(map[string]bool, len(applicableRules))
+	for _, rule := range applicableRules {
+		applicable[rule.Name] = true
+	}
+
+	r.ruleNodesMutex.Lock()
+	defer r.ruleNodesMutex.Unlock()
+
+	for ruleName, nodeNames := range r.ruleNodes {
+		if applicable[ruleName] {
+			nodeNames.Insert(nodeName)
+		} else {
+			nodeNames.Delete(nodeName)
+		}
+	}
+}
+
+// forgetRuleNodes drops the known node set of a rule
+func (r *ReadinessGateController) forgetRuleNodes(ruleName string) {
+	r.ruleNodesMutex.Lock()
+	defer r.ruleNodesMutex.Unlock()
+
+	delete(r.ruleNodes, ruleName)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// maxFailingNodes caps the number of failing nodes sampled in rule status
+const maxFailingNodes = 10
+
+// isConflictOrExists reports errors caused by a concurrent writer of the same object
+func isConflictOrExists(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+}
+
+// updateNodeReadinessStatus records the evaluation of a rule in the node's
+// NodeReadinessStatus, creating the object on first use. Evaluations that
+// did not change are not written.
+func (r *ReadinessGateController) updateNodeReadinessStatus(
+	ctx context.Context,
+	node *corev1.Node,
+	evaluation readinessv1alpha1.RuleEvaluation,
+) error {
+	return retry.OnError(retry.DefaultRetry, isConflictOrExists, func() error {
+		status := &readinessv1alpha1.NodeReadinessStatus{}
+		err := r.Get(ctx, client.ObjectKey{Name: node.Name}, status)
+		if apierrors.IsNotFound(err) {
+			status = newNodeReadinessStatus(node)
+			if err := r.Create(ctx, status); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else if !ownedByNode(status, node) {
+			// The node was re-created under the same name; start over
+			fresh := newNodeReadinessStatus(node)
+			fresh.ResourceVersion = status.ResourceVersion
+			if err := r.Update(ctx, fresh); err != nil {
+				return err
+			}
+			status = fresh
+		}
+
+		if !setRuleEvaluation(&status.Status, evaluation) {
+			return nil
+		}
+		return r.Status().Update(ctx, status)
+	})
+}
+
+// newNodeReadinessStatus returns an empty NodeReadinessStatus for a node,
+// owned by the node so that it is garbage collected along with it
+func newNodeReadinessStatus(node *corev1.Node) *readinessv1alpha1.NodeReadinessStatus {
+	return &readinessv1alpha1.NodeReadinessStatus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: node.Name,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "Node",
+				Name:       node.Name,
+				UID:        node.UID,
+			}},
+		},
+	}
+}
+
+// ownedByNode checks if a NodeReadinessStatus belongs to the given incarnation of a node
+func ownedByNode(status *readinessv1alpha1.NodeReadinessStatus, node *corev1.Node) bool {
+	for _, owner := range status.OwnerReferences {
+		if owner.Kind == "Node" && owner.UID == node.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// setRuleEvaluation stores the evaluation of a rule, returning false if it
+// matches the stored one apart from its evaluation time
+func setRuleEvaluation(status *readinessv1alpha1.NodeReadinessStatusStatus, evaluation readinessv1alpha1.RuleEvaluation) bool {
+	index := slices.IndexFunc(status.RuleEvaluations, func(existing readinessv1alpha1.RuleEvaluation) bool {
+		return existing.RuleName == evaluation.RuleName
+	})
+
+	if index >= 0 {
+		evaluation.LastEvaluated = status.RuleEvaluations[index].LastEvaluated
+		if equality.Semantic.DeepEqual(status.RuleEvaluations[index], evaluation) {
+			return false
+		}
+	}
+
+	evaluation.LastEvaluated = metav1.Now()
+	if index >= 0 {
+		status.RuleEvaluations[index] = evaluation
+	} else {
+		status.RuleEvaluations = append(status.RuleEvaluations, evaluation)
+	}
+	return true
+}
+
+// removeRuleEvaluation drops a rule's evaluation from the node's
+// NodeReadinessStatus, deleting the object once no rule evaluates the node
+func (r *ReadinessGateController) removeRuleEvaluation(ctx context.Context, nodeName, ruleName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		status := &readinessv1alpha1.NodeReadinessStatus{}
+		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, status); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		evaluations := slices.DeleteFunc(slices.Clone(status.Status.RuleEvaluations), func(evaluation readinessv1alpha1.RuleEvaluation) bool {
+			return evaluation.RuleName == ruleName
+		})
+		if len(evaluations) == len(status.Status.RuleEvaluations) {
+			return nil
+		}
+
+		if len(evaluations) == 0 {
+			return client.IgnoreNotFound(r.Delete(ctx, status))
+		}
+		status.Status.RuleEvaluations = evaluations
+		return r.Status().Update(ctx, status)
+	})
+}
+
+// retainRuleEvaluations drops the evaluations of cached rules that no longer
+// apply to the node from its NodeReadinessStatus, deleting the object once no
+// rule evaluates the node
+func (r *ReadinessGateController) retainRuleEvaluations(
+	ctx context.Context,
+	node *corev1.Node,
+	applicableRules []*readinessv1alpha1.NodeReadinessGateRule,
+) error {
+	applicable := make(map[string]bool, len(applicableRules))
+	for _, rule := range applicableRules {
+		applicable[rule.Name] = true
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		status := &readinessv1alpha1.NodeReadinessStatus{}
+		if err := r.Get(ctx, client.ObjectKey{Name: node.Name}, status); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !ownedByNode(status, node) {
+			return nil
+		}
+
+		// Evaluations of rules that are not cached yet are kept
+		evaluations := slices.DeleteFunc(slices.Clone(status.Status.RuleEvaluations), func(evaluation readinessv1alpha1.RuleEvaluation) bool {
+			return !applicable[evaluation.RuleName] && r.getCachedRule(evaluation.RuleName) != nil
+		})
+		if len(evaluations) == len(status.Status.RuleEvaluations) {
+			return nil
+		}
+
+		if len(evaluations) == 0 {
+			return client.IgnoreNotFound(r.Delete(ctx, status))
+		}
+		status.Status.RuleEvaluations = evaluations
+		return r.Status().Update(ctx, status)
+	})
+}
+
+// cleanupNodeStatusesForRule drops a deleted rule's evaluations from every NodeReadinessStatus
+func (r *ReadinessGateController) cleanupNodeStatusesForRule(ctx context.Context, ruleName string) error {
+	statusList := &readinessv1alpha1.NodeReadinessStatusList{}
+	if err := r.List(ctx, statusList); err != nil {
+		return fmt.Errorf("failed to list node readiness statuses: %w", err)
+	}
+
+	var errors []string
+	for _, status := range statusList.Items {
+		if getRuleEvaluation(&status, ruleName) == nil {
+			continue
+		}
+
+		if err := r.removeRuleEvaluation(ctx, status.Name, ruleName); err != nil {
+			errors = append(errors, fmt.Sprintf("node %s: %v", status.Name, err))
+		}
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to cleanup node readiness statuses: %s", strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// getRuleEvaluation returns the evaluation of a rule in a NodeReadinessStatus, or nil
+func getRuleEvaluation(status *readinessv1alpha1.NodeReadinessStatus, ruleName string) *readinessv1alpha1.RuleEvaluation {
+	for i := range status.Status.RuleEvaluations {
+		if status.Status.RuleEvaluations[i].RuleName == ruleName {
+			return &status.Status.RuleEvaluations[i]
+		}
+	}
+	return nil
+}
@@ -21,13 +21,18 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +48,10 @@ import (
 const (
 	// finalizerName is the finalizer added to NodeReadinessGateRule to ensure cleanup
 	finalizerName = "nodereadiness.io/cleanup-taints"
+
+	// defaultNodeWorkers is the number of nodes evaluated in parallel when a
+	// rule is processed against all nodes
+	defaultNodeWorkers = 10
 )
 
 // ReadinessGateController manages node taints based on readiness gate rules
@@ -54,9 +63,67 @@ type ReadinessGateController struct {
 	// Cache for efficient rule lookup
 	ruleCacheMutex sync.RWMutex
 	ruleCache      map[string]*readinessv1alpha1.NodeReadinessGateRule // ruleName -> rule
-
-	// Global dry run mode (emergency off-switch)
-	globalDryRun bool
+	compiledRules  map[string]*compiledRule                            // ruleName -> parsed selector, conditions and expressions
+
+	// Nodes each rule applied to when it was last processed, kept up to date
+	// as node labels change
+	ruleNodesMutex sync.Mutex
+	ruleNodes      map[string]sets.Set[string] // ruleName -> node names
+
+	// Set once the node label index is registered with the manager cache
+	nodeLabelIndexed bool
+
+	// Tracks when nodes started failing a rule, used to honor grace periods
+	failingSinceMutex sync.Mutex
+	failingSince      map[string]map[string]time.Time // ruleName -> nodeName -> first failure
+
+	// Latest outcome of each rule on each node, aggregated into rule status
+	nodeOutcomesMutex sync.Mutex
+	nodeOutcomes      map[string]map[string]nodeOutcome // ruleName -> nodeName -> outcome
+	rebuiltOutcomes   sets.Set[string]                  // rules whose outcomes cover all their nodes
+
+	// Guards the failing node samples of rules, which are updated by
+	// concurrent node workers and node reconciles
+	failingNodesMutex sync.Mutex
+
+	// Number of nodes evaluated in parallel by processAllNodesForRule
+	nodeWorkers int
+
+	// Coalesces rule status writes from node events
+	statusBatcherOnce sync.Once
+	statusBatcher     *statusBatcher
+
+	// Global dry run mode (emergency off-switch), set by flag or toggled at
+	// runtime through a ConfigMap
+	globalDryRun  atomic.Bool
+	runtimeDryRun atomic.Bool
+
+	// Requeues rules from outside the rule controller, e.g. when the global
+	// dry run is toggled
+	ruleEvents chan event.TypedGenericEvent[*readinessv1alpha1.NodeReadinessGateRule]
+
+	// Limits how many nodes rules may gate; breakers record the rules whose
+	// taint additions are held back
+	breakerMutex         sync.Mutex
+	globalMaxUnavailable *intstr.IntOrString
+	taintBreakers        map[string]*taintBreaker // ruleName -> reason taints are held back
+
+	// Rollout progress of rules, restored from their status on reconcile
+	rolloutsMutex sync.Mutex
+	rollouts      map[string]rolloutState // ruleName -> last released node
+
+	// Canary promotion of rules, restored from their status on reconcile
+	canariesMutex sync.Mutex
+	canaries      map[string]canaryState // ruleName -> promotion
+
+	// Namespace the full dry run plan of each rule is published in, empty
+	// to only sample it in rule status
+	dryRunPlanNamespace string
+	dryRunPlansMutex    sync.Mutex
+	publishedPlans      map[string]uint64 // ruleName -> digest of the last published plan
+
+	// Records Events on rules, nil in tests that do not check them
+	recorder record.EventRecorder
 }
 
 // NewReadinessGateController creates a new controller
@@ -66,6 +133,37 @@ func NewReadinessGateController(mgr ctrl.Manager, clientset kubernetes.Interface
 		Scheme:    mgr.GetScheme(),
 		clientset: clientset,
 		ruleCache: make(map[string]*readinessv1alpha1.NodeReadinessGateRule),
+
+		compiledRules: make(map[string]*compiledRule),
+		ruleNodes:     make(map[string]sets.Set[string]),
+		failingSince:  make(map[string]map[string]time.Time),
+		nodeOutcomes:  make(map[string]map[string]nodeOutcome),
+
+		statusBatcher: newStatusBatcher(),
+		nodeWorkers:   defaultNodeWorkers,
+
+		ruleEvents: make(chan event.TypedGenericEvent[*readinessv1alpha1.NodeReadinessGateRule], 100),
+
+		taintBreakers:  make(map[string]*taintBreaker),
+		rollouts:       make(map[string]rolloutState),
+		canaries:       make(map[string]canaryState),
+		publishedPlans: make(map[string]uint64),
+		recorder:       mgr.GetEventRecorderFor("nodereadiness-controller"),
+	}
+}
+
+// SetNodeWorkers sets how many nodes are evaluated in parallel when a rule
+// is processed against all nodes
+func (r *ReadinessGateController) SetNodeWorkers(workers int) {
+	if workers > 0 {
+		r.nodeWorkers = workers
+	}
+}
+
+// recordEvent records an Event on a rule
+func (r *ReadinessGateController) recordEvent(rule *readinessv1alpha1.NodeReadinessGateRule, eventType, reason, message string) {
+	if r.recorder != nil {
+		r.recorder.Event(rule, eventType, reason, message)
 	}
 }
 
@@ -74,11 +172,17 @@ type RuleReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	Controller *ReadinessGateController
+
+	// MaxConcurrentReconciles is the number of rules reconciled in parallel. Defaults to 1.
+	MaxConcurrentReconciles int
 }
 
 // +kubebuilder:rbac:groups=nodereadiness.io,resources=nodereadinessgaterules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nodereadiness.io,resources=nodereadinessgaterules/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nodereadiness.io,resources=nodereadinessgaterules/finalizers,verbs=update
+// +kubebuilder:rbac:groups=nodereadiness.io,resources=nodereadinessstatuses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nodereadiness.io,resources=nodereadinessstatuses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -99,6 +203,13 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	// Handle deletion with finalizer
 	if rule.DeletionTimestamp != nil {
 		if containsFinalizer(rule, finalizerName) {
+			// Taints stay in place until the global dry run is lifted, which
+			// requeues every rule
+			if r.Controller.isGlobalDryRun() {
+				log.Info("Global dry run enabled, deferring taint cleanup for deleted rule", "rule", rule.Name)
+				return ctrl.Result{}, nil
+			}
+
 			// Rule is being deleted, clean up taints before removing finalizer
 			log.Info("Cleaning up taints for deleted rule", "rule", rule.Name)
 			if err := r.Controller.cleanupTaintsForRule(ctx, rule); err != nil {
@@ -106,6 +217,18 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 				return ctrl.Result{RequeueAfter: time.Minute}, err
 			}
 
+			// Drop the rule's evaluations from all NodeReadinessStatus objects
+			if err := r.Controller.cleanupNodeStatusesForRule(ctx, rule.Name); err != nil {
+				log.Error(err, "Failed to cleanup node readiness statuses for rule", "rule", rule.Name)
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+
+			// Drop the rule's bootstrap completion records from all nodes
+			if err := r.Controller.cleanupBootstrapStateForRule(ctx, rule); err != nil {
+				log.Error(err, "Failed to cleanup bootstrap state for rule", "rule", rule.Name)
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+
 			// Remove finalizer
 			removeFinalizer(rule, finalizerName)
 			if err := r.Update(ctx, rule); err != nil {
@@ -135,8 +258,29 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		}
 	}
 
-	// Detect nodeSelector changes and cleanup old nodes
+	// Detect taint key, value and effect changes and migrate nodes to the new
+	// taints before they are applied, so the old ones are not orphaned
 	cachedRule := r.Controller.getCachedRule(rule.Name)
+
+	// While the global dry run is on no taint is touched. The enforced version
+	// of the rule stays cached, so that spec changes are migrated once the
+	// dry run is lifted and every rule is requeued.
+	if r.Controller.isGlobalDryRun() {
+		return r.reconcileGlobalDryRun(ctx, rule, cachedRule)
+	}
+
+	if previousTaints, known := appliedTaints(rule, cachedRule); known {
+		if migrations := taintMigrations(previousTaints, rule.Spec.GetTaints()); len(migrations) > 0 {
+			log.Info("Taint spec changed, migrating nodes to the new taints", "rule", rule.Name)
+			if err := r.Controller.migrateTaintsAfterSpecChange(ctx, rule, cachedRule, migrations); err != nil {
+				log.Error(err, "Failed to migrate taints after spec change", "rule", rule.Name)
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+		}
+	}
+	rule.Status.AppliedTaints = rule.Spec.GetTaints()
+
+	// Detect nodeSelector changes and cleanup old nodes
 	if cachedRule != nil && nodeSelectorChanged(rule.Spec.NodeSelector, cachedRule.Spec.NodeSelector) {
 		log.Info("NodeSelector changed, cleaning up nodes from old selector", "rule", rule.Name)
 		if err := r.Controller.cleanupNodesAfterSelectorChange(ctx, cachedRule, rule); err != nil {
@@ -152,21 +296,56 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		log.V(4).Info("Finalizer added to rule", "rule", rule.Name)
 	}
 
+	// A rule whose node selector cannot be parsed applies to no node. Report
+	// it in the status once rather than failing every reconcile.
+	if _, err := r.Controller.ruleSelector(rule); err != nil {
+		log.Info("Rule has an invalid node selector, skipping node processing", "rule", rule.Name, "error", err.Error())
+		r.Controller.resetNodeOutcomes(rule.Name)
+		r.Controller.markNodeOutcomesRebuilt(rule.Name)
+		rule.Status.ObservedGeneration = rule.Generation
+		if err := r.Controller.updateRuleStatus(ctx, rule); err != nil {
+			log.Error(err, "Failed to update rule status", "rule", rule.Name)
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// Handle dry run
+	var requeueAfter time.Duration
 	if rule.Spec.DryRun {
-		if err := r.Controller.processDryRun(ctx, rule); err != nil {
-			log.Error(err, "Failed to process dry run", "rule", rule.Name)
+		nodes, err := r.Controller.listRuleNodes(ctx, rule)
+		if err != nil {
+			log.Error(err, "Failed to list nodes for rule", "rule", rule.Name)
 			return ctrl.Result{RequeueAfter: time.Minute}, err
 		}
+		r.Controller.setRuleNodes(rule.Name, nodes)
+		r.Controller.processDryRun(ctx, rule, nodes)
+		requeueAfter = dryRunRefreshInterval
+
+		// Enforcement starts with a new rollout and canary once the rule
+		// leaves dry run
+		rule.Status.Rollout = nil
+		r.Controller.forgetRollout(rule.Name)
+		rule.Status.Canary = nil
+		r.Controller.forgetCanary(rule.Name)
 	} else {
 		// Clear previous dry run results
+		previousDryRun := rule.Status.DryRunResults
 		rule.Status.DryRunResults = nil
 
 		// Process all applicable nodes for this rule
-		if err := r.Controller.processAllNodesForRule(ctx, rule); err != nil {
+		var err error
+		if requeueAfter, err = r.Controller.processAllNodesForRule(ctx, rule); err != nil {
 			log.Error(err, "Failed to process nodes for rule", "rule", rule.Name)
 			return ctrl.Result{RequeueAfter: time.Minute}, err
 		}
+
+		// The published plan goes away once no node is in dry run any more
+		if rule.Status.DryRunResults == nil {
+			if err := r.Controller.deleteDryRunPlan(ctx, rule.Name, previousDryRun); err != nil {
+				log.Error(err, "Failed to delete dry run plan", "rule", rule.Name)
+			}
+		}
 	}
 
 	// Update rule status
@@ -181,40 +360,79 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
-	return ctrl.Result{}, nil
+	// Requeue when the earliest pending grace period expires
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
-// cleanupDeletedNodes removes status entries for nodes that no longer exist
-func (r *ReadinessGateController) cleanupDeletedNodes(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+// reconcileGlobalDryRun reports the impact of a rule while the global dry run
+// freezes all taint changes
+func (r *RuleReconciler) reconcileGlobalDryRun(
+	ctx context.Context,
+	rule, cachedRule *readinessv1alpha1.NodeReadinessGateRule,
+) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
+	log.Info("Global dry run enabled, reporting rule impact without changing nodes", "rule", rule.Name)
 
-	nodeList := &corev1.NodeList{}
-	if err := r.List(ctx, nodeList); err != nil {
-		return err
+	if cachedRule == nil {
+		r.Controller.updateRuleCache(ctx, rule)
+	}
+
+	if _, err := r.Controller.ruleSelector(rule); err == nil {
+		nodes, err := r.Controller.listRuleNodes(ctx, rule)
+		if err != nil {
+			log.Error(err, "Failed to list nodes for rule", "rule", rule.Name)
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		// The known nodes of an outdated cached version are kept for migration
+		if cachedRule == nil || cachedRule.Generation == rule.Generation {
+			r.Controller.setRuleNodes(rule.Name, nodes)
+		}
+		r.Controller.processDryRun(ctx, rule, nodes)
+	} else {
+		r.Controller.resetNodeOutcomes(rule.Name)
+		r.Controller.markNodeOutcomesRebuilt(rule.Name)
 	}
 
-	existingNodes := make(map[string]bool)
-	for _, node := range nodeList.Items {
-		existingNodes[node.Name] = true
+	if err := r.Controller.updateRuleStatus(ctx, rule); err != nil {
+		log.Error(err, "Failed to update rule status", "rule", rule.Name)
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	return ctrl.Result{RequeueAfter: dryRunRefreshInterval}, nil
+}
+
+// cleanupDeletedNodes removes failing node samples for nodes that no longer
+// exist. Per-node evaluations are garbage collected with their node.
+func (r *ReadinessGateController) cleanupDeletedNodes(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	// The failing node sample is small, so look its nodes up one by one
+	deletedNodes := make(map[string]bool)
+	for _, failure := range rule.Status.FailingNodes {
+		if err := r.Get(ctx, client.ObjectKey{Name: failure.NodeName}, &corev1.Node{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			deletedNodes[failure.NodeName] = true
+		}
 	}
 
 	// Filter out deleted nodes
-	var newNodeEvaluations []readinessv1alpha1.NodeEvaluation
-	for _, evaluation := range rule.Status.NodeEvaluations {
-		if existingNodes[evaluation.NodeName] {
-			newNodeEvaluations = append(newNodeEvaluations, evaluation)
+	var newFailingNodes []readinessv1alpha1.NodeFailure
+	for _, failure := range rule.Status.FailingNodes {
+		if !deletedNodes[failure.NodeName] {
+			newFailingNodes = append(newFailingNodes, failure)
 		}
 	}
 
-	if len(newNodeEvaluations) == len(rule.Status.NodeEvaluations) {
+	if len(newFailingNodes) == len(rule.Status.FailingNodes) {
 		log.V(4).Info("No deleted nodes to clean up", "rule", rule.Name)
 		return nil
 	}
 
 	log.V(4).Info("Cleaning up deleted nodes from rule status",
 		"rule", rule.Name,
-		"before", len(rule.Status.NodeEvaluations),
-		"after", len(newNodeEvaluations))
+		"before", len(rule.Status.FailingNodes),
+		"after", len(newFailingNodes))
 
 	// Use retry on conflict to update status to avoid race conditions from node updates
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -223,110 +441,245 @@ func (r *ReadinessGateController) cleanupDeletedNodes(ctx context.Context, rule
 			return err
 		}
 
-		var freshNodeEvaluations []readinessv1alpha1.NodeEvaluation
-		for _, evaluation := range fresh.Status.NodeEvaluations {
-			if existingNodes[evaluation.NodeName] {
-				freshNodeEvaluations = append(freshNodeEvaluations, evaluation)
+		var freshFailingNodes []readinessv1alpha1.NodeFailure
+		for _, failure := range fresh.Status.FailingNodes {
+			if !deletedNodes[failure.NodeName] {
+				freshFailingNodes = append(freshFailingNodes, failure)
 			}
 		}
 
-		if len(freshNodeEvaluations) == len(fresh.Status.NodeEvaluations) {
+		if len(freshFailingNodes) == len(fresh.Status.FailingNodes) {
 			return nil
 		}
 
-		fresh.Status.NodeEvaluations = freshNodeEvaluations
+		fresh.Status.FailingNodes = freshFailingNodes
 		return r.Status().Update(ctx, fresh)
 	})
 }
 
-// processAllNodesForRule processes all nodes when a rule changes
-func (r *ReadinessGateController) processAllNodesForRule(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) error {
+// processAllNodesForRule processes all nodes when a rule changes. It returns
+// the earliest time at which a node needs to be re-evaluated, or zero.
+func (r *ReadinessGateController) processAllNodesForRule(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) (time.Duration, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	nodeList := &corev1.NodeList{}
-	if err := r.List(ctx, nodeList); err != nil {
-		return err
+	nodes, err := r.listRuleNodes(ctx, rule)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info("Processing all nodes for rule", "rule", rule.Name, "matchedNodes", len(nodes))
+	r.setRuleNodes(rule.Name, nodes)
+
+	// Release the next rollout batch, if one is due
+	requeueAfter := r.advanceRollout(ctx, rule, nodes)
+
+	// While a canary is active, taints are only added to the canary nodes
+	r.syncCanary(rule)
+
+	// Node outcomes are rebuilt from scratch so nodes that stopped matching
+	// drop out. When taint additions are limited, the nodes that are already
+	// gated are counted first, so that maxUnavailable holds while the nodes
+	// are evaluated in parallel.
+	r.resetNodeOutcomes(rule.Name)
+	if r.maxUnavailableEnforced(rule) {
+		sim := r.simulateRule(rule, nodes)
+		r.markNodeOutcomesRebuilt(rule.Name)
+		if limit, ok := maxUnavailableLimit(rule.Spec.MaxUnavailable, sim.affectedNodes); ok &&
+			sim.gatedNodes-sim.taintsToRemove+sim.taintsToAdd > limit {
+			log.Info("Rule would gate more nodes than maxUnavailable allows, some taints will be held back",
+				"rule", rule.Name, "gatedNodes", sim.gatedNodes, "taintsToAdd", sim.taintsToAdd,
+				"taintsToRemove", sim.taintsToRemove, "maxUnavailable", limit)
+		}
 	}
 
-	log.Info("Processing all nodes for rule", "rule", rule.Name, "totalNodes", len(nodeList.Items))
+	// Nodes are evaluated in parallel; results are merged under resultMutex
+	var resultMutex sync.Mutex
+	var matchedNodes int32
+	enforce := func(node *corev1.Node) {
+		log.Info("Processing node for rule", "rule", rule.Name, "node", node.Name)
+		nodeRequeue, err := r.evaluateRuleForNode(ctx, rule, node)
+		if err != nil {
+			// Log error but continue with other nodes
+			log.Error(err, "Failed to evaluate node for rule", "rule", rule.Name, "node", node.Name)
+			r.recordNodeFailure(rule, node.Name, "EvaluationError", err.Error())
+		} else {
+			r.clearNodeFailure(rule, node.Name)
+		}
+
+		resultMutex.Lock()
+		defer resultMutex.Unlock()
+		matchedNodes++
+		requeueAfter = minRequeue(requeueAfter, nodeRequeue)
+	}
+	if err = r.forEachNode(ctx, nodes, enforce); err != nil {
+		return 0, err
+	}
 
-	var appliedNodes []string
-	for _, node := range nodeList.Items {
-		if r.ruleAppliesTo(ctx, rule, &node) {
-			appliedNodes = append(appliedNodes, node.Name)
-			log.Info("Processing node for rule", "rule", rule.Name, "node", node.Name)
-			if err := r.evaluateRuleForNode(ctx, rule, &node); err != nil {
-				// Log error but continue with other nodes
-				log.Error(err, "Failed to evaluate node for rule", "rule", rule.Name, "node", node.Name)
-				r.recordNodeFailure(rule, node.Name, "EvaluationError", err.Error())
+	// The other nodes get their taints once the canary is promoted, and
+	// report what would be added until then
+	if rule.Spec.Canary != nil && r.canaryActive(rule) {
+		canaryNodes, otherNodes := r.splitCanaryNodes(rule, nodes)
+		promoted, canaryRequeue := r.updateCanary(ctx, rule, canaryNodes)
+		if promoted {
+			if err = r.forEachNode(ctx, otherNodes, enforce); err != nil {
+				return 0, err
 			}
+		} else {
+			r.recordDryRunPlan(rule.Name, r.simulateRule(rule, otherNodes))
+			r.reportDryRun(ctx, rule)
+			requeueAfter = minRequeue(requeueAfter, minRequeue(canaryRequeue, dryRunRefreshInterval))
 		}
 	}
+	r.refreshTaintBreaker(ctx, rule)
+	r.markNodeOutcomesRebuilt(rule.Name)
 
 	// Update status
 	rule.Status.ObservedGeneration = rule.Generation
-	rule.Status.AppliedNodes = appliedNodes
 
-	log.Info("Completed processing nodes for rule", "rule", rule.Name, "processedCount", len(appliedNodes))
-	return nil
+	log.Info("Completed processing nodes for rule", "rule", rule.Name, "processedCount", matchedNodes)
+	return requeueAfter, nil
 }
 
-// evaluateRuleForNode evaluates a single rule against a single node
-func (r *ReadinessGateController) evaluateRuleForNode(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) error {
-	log := ctrl.LoggerFrom(ctx)
-
-	// Evaluate all conditions (ALL logic)
-	allConditionsSatisfied := true
-	conditionResults := make([]readinessv1alpha1.ConditionEvaluationResult, 0, len(rule.Spec.Conditions))
+// forEachNode calls process for every node, with at most nodeWorkers calls
+// running at a time. It stops handing out nodes once the context is cancelled.
+func (r *ReadinessGateController) forEachNode(ctx context.Context, nodes []corev1.Node, process func(node *corev1.Node)) error {
+	workers := r.nodeWorkers
+	if workers <= 0 {
+		workers = defaultNodeWorkers
+	}
 
-	for _, condReq := range rule.Spec.Conditions {
-		currentStatus := r.getConditionStatus(node, condReq.Type)
-		satisfied := currentStatus == condReq.RequiredStatus
-		missing := currentStatus == corev1.ConditionUnknown
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, workers)
+	defer wg.Wait()
 
-		if !satisfied {
-			allConditionsSatisfied = false
+	for i := range nodes {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case slots <- struct{}{}:
 		}
 
-		conditionResults = append(conditionResults, readinessv1alpha1.ConditionEvaluationResult{
-			Type:           condReq.Type,
-			CurrentStatus:  currentStatus,
-			RequiredStatus: condReq.RequiredStatus,
-			Satisfied:      satisfied,
-			Missing:        missing,
-		})
+		wg.Add(1)
+		go func(node *corev1.Node) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			process(node)
+		}(&nodes[i])
+	}
+	return nil
+}
+
+// evaluateRuleForNode evaluates a single rule against a single node. The
+// returned duration is non-zero when the node must be re-evaluated later,
+// e.g. because a grace period is still running.
+func (r *ReadinessGateController) evaluateRuleForNode(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) (time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Completed bootstrap-only rules are not enforced again, unless the node
+	// changed in a way that re-arms them
+	skip, err := r.skipCompletedBootstrap(ctx, node, rule)
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-arm bootstrap-only rule: %w", err)
+	}
+	if skip {
+		return 0, nil
+	}
 
+	// Evaluate all conditions and condition groups
+	eval := r.evaluateConditions(rule, node)
+	allConditionsSatisfied := eval.satisfied
+
+	for _, result := range eval.conditionResults {
 		log.V(1).Info("Condition evaluation", "node", node.Name, "rule", rule.Name,
-			"conditionType", condReq.Type, "current", currentStatus, "required", condReq.RequiredStatus,
-			"satisfied", satisfied, "missing", missing)
+			"conditionType", result.Type, "group", result.Group, "current", result.CurrentStatus,
+			"required", result.RequiredStatus, "satisfied", result.Satisfied, "missing", result.Missing,
+			"stale", result.Stale)
+	}
+	for _, result := range eval.groupResults {
+		log.V(1).Info("Condition group evaluation", "node", node.Name, "rule", rule.Name,
+			"group", result.Name, "operator", result.Operator, "satisfiedCount", result.SatisfiedCount,
+			"total", result.Total, "satisfied", result.Satisfied)
+	}
+	for _, result := range eval.expressionResults {
+		log.V(1).Info("Expression evaluation", "node", node.Name, "rule", rule.Name,
+			"expression", result.Name, "satisfied", result.Satisfied, "error", result.Error)
 	}
 
-	// Determine taint action
+	// Determine gate action. All taints and other gate actions of a rule are
+	// applied and reverted together.
+	taints := rule.Spec.GetTaints()
 	shouldRemoveTaint := allConditionsSatisfied
-	currentlyHasTaint := r.hasTaintBySpec(node, rule.Spec.Taint)
+	currentlyHasTaint, hasAllTaints := r.gateApplied(node, rule)
+	// A satisfied node is released if it is gated, or if its condition action
+	// has not reported it ready yet
+	canRelease := currentlyHasTaint || r.awaitsReadyCondition(node, rule)
 
 	log.Info("Evaluation result", "node", node.Name, "rule", rule.Name,
 		"allConditionsSatisfied", allConditionsSatisfied, "hasTaint", currentlyHasTaint)
 
-	var err error
+	requeueAfter := eval.requeueAfter
+	var pendingTaintUntil, pendingRemovalUntil *metav1.Time
+	heldBack := false
+
+	// Failure tracking only matters while the taint is waiting to be added
+	if shouldRemoveTaint || currentlyHasTaint {
+		r.clearFailingSince(rule.Name, node.Name)
+	}
+
+	// Conditions must also have been satisfied for the stableFor window
+	removalDeadline := stableDeadline(rule, eval.satisfiedSince)
 
-	if shouldRemoveTaint && currentlyHasTaint {
-		log.Info("Removing taint", "node", node.Name, "rule", rule.Name, "taint", rule.Spec.Taint.Key)
+	if shouldRemoveTaint && canRelease && !removalDeadline.IsZero() {
+		log.Info("Deferring taint removal until conditions are stable", "node", node.Name, "rule", rule.Name,
+			"taints", taints, "pendingUntil", removalDeadline)
+		requeueAfter = minRequeue(requeueAfter, time.Until(removalDeadline))
+		pendingRemovalUntil = &metav1.Time{Time: removalDeadline}
 
-		if err = r.removeTaintBySpec(ctx, node, rule.Spec.Taint); err != nil {
-			return fmt.Errorf("failed to remove taint: %w", err)
+	} else if shouldRemoveTaint && canRelease {
+		log.Info("Removing taints", "node", node.Name, "rule", rule.Name, "taints", taints, "actions", rule.Spec.Actions)
+
+		if err = r.removeGateActions(ctx, node, rule); err != nil {
+			return 0, err
 		}
 
 		// Mark bootstrap completed if bootstrap-only mode
-		if rule.Spec.EnforcementMode == readinessv1alpha1.EnforcementModeBootstrapOnly {
-			r.markBootstrapCompleted(ctx, node.Name, rule.Name)
+		if rule.Spec.EnforcementMode == readinessv1alpha1.EnforcementModeBootstrapOnly && !isBootstrapCompleted(node, rule) {
+			r.markBootstrapCompleted(ctx, node.Name, rule)
 		}
 
-	} else if !shouldRemoveTaint && !currentlyHasTaint {
-		log.Info("Adding taint", "node", node.Name, "rule", rule.Name, "taint", rule.Spec.Taint.Key)
+	} else if !shouldRemoveTaint && !hasAllTaints {
+		// The grace period only applies to healthy nodes; a node that already
+		// carries some of the taints gets the rest immediately
+		if deadline, pending := r.gracePeriodDeadline(ctx, rule, node); pending && !currentlyHasTaint {
+			log.Info("Deferring taint until grace period expires", "node", node.Name, "rule", rule.Name,
+				"taints", taints, "pendingUntil", deadline)
+			requeueAfter = minRequeue(requeueAfter, time.Until(deadline))
+			pendingTaintUntil = &metav1.Time{Time: deadline}
+		} else if !currentlyHasTaint && !r.rolloutReached(rule, node) {
+			// A later rollout batch releases the node
+			log.Info("Holding back taints until the rollout reaches the node", "node", node.Name, "rule", rule.Name,
+				"taints", taints)
+			heldBack = true
+		} else if !currentlyHasTaint && !r.canaryReached(rule, node) {
+			// The canary is promoted to the other nodes later
+			log.Info("Holding back taints until the canary is promoted", "node", node.Name, "rule", rule.Name,
+				"taints", taints)
+			heldBack = true
+		} else if !currentlyHasTaint && !r.reserveTaintAddition(ctx, rule, node.Name) {
+			// Too many nodes are gated already; removals elsewhere free up room
+			log.Info("Holding back taints, maxUnavailable reached", "node", node.Name, "rule", rule.Name,
+				"taints", taints)
+			requeueAfter = minRequeue(requeueAfter, maxUnavailableRecheck)
+			heldBack = true
+		} else {
+			log.Info("Adding taints", "node", node.Name, "rule", rule.Name, "taints", taints, "actions", rule.Spec.Actions)
 
-		if err = r.addTaintBySpec(ctx, node, rule.Spec.Taint); err != nil {
-			return fmt.Errorf("failed to add taint: %w", err)
+			if err = r.applyGateActions(ctx, node, rule); err != nil {
+				// Release the node counted as gated by reserveTaintAddition
+				r.recordNodeOutcome(rule.Name, node.Name, nodeOutcome{})
+				return 0, err
+			}
+			r.clearFailingSince(rule.Name, node.Name)
 		}
 	} else {
 		log.Info("No taint action needed", "node", node.Name, "rule", rule.Name,
@@ -335,45 +688,167 @@ func (r *ReadinessGateController) evaluateRuleForNode(ctx context.Context, rule
 
 	// Determine observed taint status after any actions
 	var taintStatus string
-	if r.hasTaintBySpec(node, rule.Spec.Taint) {
+	if gated, _ := r.gateApplied(node, rule); gated {
 		taintStatus = "Present"
+	} else if pendingTaintUntil != nil {
+		taintStatus = "Pending"
+	} else if heldBack {
+		taintStatus = "HeldBack"
 	} else {
 		taintStatus = "Absent"
 	}
 
-	// Update evaluation status
-	r.updateNodeEvaluationStatus(rule, node.Name, conditionResults, taintStatus)
+	r.recordNodeOutcome(rule.Name, node.Name, nodeOutcome{
+		satisfied: allConditionsSatisfied,
+		gated:     taintStatus == "Present",
+		pending:   pendingTaintUntil != nil || pendingRemovalUntil != nil,
+	})
+
+	// Report the evaluation in the node's NodeReadinessStatus
+	if err = r.updateNodeReadinessStatus(ctx, node, readinessv1alpha1.RuleEvaluation{
+		RuleName:            rule.Name,
+		ConditionResults:    eval.conditionResults,
+		GroupResults:        eval.groupResults,
+		ExpressionResults:   eval.expressionResults,
+		TaintStatus:         taintStatus,
+		PendingTaintUntil:   pendingTaintUntil,
+		PendingRemovalUntil: pendingRemovalUntil,
+	}); err != nil {
+		return requeueAfter, fmt.Errorf("failed to update node readiness status: %w", err)
+	}
 
-	return nil
+	return requeueAfter, nil
 }
 
-// updateNodeEvaluationStatus updates the evaluation status for a specific node
-func (r *ReadinessGateController) updateNodeEvaluationStatus(
+// gracePeriodDeadline records when the node started failing the rule and
+// reports whether the taint should still be held back. Rules without a grace
+// period never defer, and neither do nodes that never satisfied the rule,
+// such as new nodes that are still bootstrapping. A grace period that started
+// before a restart keeps the deadline reported in the NodeReadinessStatus.
+func (r *ReadinessGateController) gracePeriodDeadline(
+	ctx context.Context,
 	rule *readinessv1alpha1.NodeReadinessGateRule,
-	nodeName string,
-	conditionResults []readinessv1alpha1.ConditionEvaluationResult,
-	taintStatus string,
-) {
-	// Find existing evaluation or create new
-	var nodeEval *readinessv1alpha1.NodeEvaluation
-	for i := range rule.Status.NodeEvaluations {
-		if rule.Status.NodeEvaluations[i].NodeName == nodeName {
-			nodeEval = &rule.Status.NodeEvaluations[i]
-			break
+	node *corev1.Node,
+) (time.Time, bool) {
+	if rule.Spec.GracePeriod == nil || rule.Spec.GracePeriod.Duration <= 0 {
+		return time.Time{}, false
+	}
+	nodeName := node.Name
+	start := time.Now()
+	if !r.isFailingSinceTracked(rule.Name, nodeName) {
+		evaluation := r.lastRuleEvaluation(ctx, rule, node)
+		if evaluation != nil && evaluation.TaintStatus == "Pending" && evaluation.PendingTaintUntil != nil {
+			start = evaluation.PendingTaintUntil.Add(-rule.Spec.GracePeriod.Duration)
+		} else if !r.hasSatisfiedRule(rule, node, evaluation) {
+			return time.Time{}, false
+		}
+	}
+
+	r.failingSinceMutex.Lock()
+	defer r.failingSinceMutex.Unlock()
+
+	if r.failingSince == nil {
+		r.failingSince = make(map[string]map[string]time.Time)
+	}
+	nodes, exists := r.failingSince[rule.Name]
+	if !exists {
+		nodes = make(map[string]time.Time)
+		r.failingSince[rule.Name] = nodes
+	}
+	since, exists := nodes[nodeName]
+	if !exists {
+		since = start
+		nodes[nodeName] = since
+	}
+
+	deadline := since.Add(rule.Spec.GracePeriod.Duration)
+	return deadline, time.Now().Before(deadline)
+}
+
+// stableDeadline returns when conditions satisfied since the given time will
+// have been stable for the rule's stableFor window, or the zero time if the
+// taint can be removed now
+func stableDeadline(rule *readinessv1alpha1.NodeReadinessGateRule, satisfiedSince time.Time) time.Time {
+	if rule.Spec.StableFor == nil || rule.Spec.StableFor.Duration <= 0 || satisfiedSince.IsZero() {
+		return time.Time{}
+	}
+
+	deadline := satisfiedSince.Add(rule.Spec.StableFor.Duration)
+	if !time.Now().Before(deadline) {
+		return time.Time{}
+	}
+	return deadline
+}
+
+// isFailingSinceTracked checks if a node's grace period for a rule has started
+func (r *ReadinessGateController) isFailingSinceTracked(ruleName, nodeName string) bool {
+	r.failingSinceMutex.Lock()
+	defer r.failingSinceMutex.Unlock()
+
+	_, exists := r.failingSince[ruleName][nodeName]
+	return exists
+}
+
+// hasSatisfiedRule checks if a node satisfied a rule when it was last
+// evaluated, either in this process or as recorded in the given evaluation
+// from its NodeReadinessStatus
+func (r *ReadinessGateController) hasSatisfiedRule(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node, evaluation *readinessv1alpha1.RuleEvaluation) bool {
+	r.nodeOutcomesMutex.Lock()
+	outcome, exists := r.nodeOutcomes[rule.Name][node.Name]
+	r.nodeOutcomesMutex.Unlock()
+	if exists {
+		return outcome.satisfied
+	}
+	return evaluation != nil && (evaluation.TaintStatus == "Absent" || evaluation.TaintStatus == "Pending")
+}
+
+// lastRuleEvaluation returns the evaluation of a rule recorded in the node's
+// NodeReadinessStatus, or nil if there is none
+func (r *ReadinessGateController) lastRuleEvaluation(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) *readinessv1alpha1.RuleEvaluation {
+	status := &readinessv1alpha1.NodeReadinessStatus{}
+	if err := r.Get(ctx, client.ObjectKey{Name: node.Name}, status); err != nil || !ownedByNode(status, node) {
+		return nil
+	}
+	return getRuleEvaluation(status, rule.Name)
+}
+
+// clearFailingSince forgets when a node started failing a rule
+func (r *ReadinessGateController) clearFailingSince(ruleName, nodeName string) {
+	r.failingSinceMutex.Lock()
+	defer r.failingSinceMutex.Unlock()
+
+	if nodes, exists := r.failingSince[ruleName]; exists {
+		delete(nodes, nodeName)
+		if len(nodes) == 0 {
+			delete(r.failingSince, ruleName)
 		}
 	}
+}
 
-	if nodeEval == nil {
-		rule.Status.NodeEvaluations = append(rule.Status.NodeEvaluations, readinessv1alpha1.NodeEvaluation{
-			NodeName: nodeName,
-		})
-		nodeEval = &rule.Status.NodeEvaluations[len(rule.Status.NodeEvaluations)-1]
+// forgetNode drops all failure tracking and outcomes for a deleted node
+func (r *ReadinessGateController) forgetNode(nodeName string) {
+	r.failingSinceMutex.Lock()
+	for ruleName, nodes := range r.failingSince {
+		delete(nodes, nodeName)
+		if len(nodes) == 0 {
+			delete(r.failingSince, ruleName)
+		}
 	}
+	r.failingSinceMutex.Unlock()
 
-	// Update evaluation
-	nodeEval.ConditionResults = conditionResults
-	nodeEval.TaintStatus = taintStatus
-	nodeEval.LastEvaluated = metav1.Now()
+	r.retainNodeOutcomes(nodeName, nil)
+	r.updateRuleNodes(nodeName, nil)
+}
+
+// minRequeue returns the smaller non-zero requeue duration
+func minRequeue(a, b time.Duration) time.Duration {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 || a < b {
+		return a
+	}
+	return b
 }
 
 // getApplicableRulesForNode returns all rules applicable to a node
@@ -383,8 +858,9 @@ func (r *ReadinessGateController) getApplicableRulesForNode(ctx context.Context,
 
 	var applicableRules []*readinessv1alpha1.NodeReadinessGateRule
 
+	// Selectors are parsed once per rule update; invalid ones are reported in rule status
 	for _, rule := range r.ruleCache {
-		if r.ruleAppliesTo(ctx, rule, node) {
+		if r.cachedCompiledRule(rule).matches(node.Labels) {
 			applicableRules = append(applicableRules, rule)
 		}
 	}
@@ -392,21 +868,10 @@ func (r *ReadinessGateController) getApplicableRulesForNode(ctx context.Context,
 	return applicableRules
 }
 
-// ruleAppliesTo checks if a rule applies to a node
+// ruleAppliesTo checks if a rule applies to a node. Rules with an invalid
+// node selector apply to no node.
 func (r *ReadinessGateController) ruleAppliesTo(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) bool {
-	log := ctrl.LoggerFrom(ctx)
-
-	if rule.Spec.NodeSelector == nil {
-		return true
-	}
-
-	selector, err := metav1.LabelSelectorAsSelector(rule.Spec.NodeSelector)
-	if err != nil {
-		log.Error(err, "Invalid node selector for rule", "rule", rule.Name)
-		return false
-	}
-
-	return selector.Matches(labels.Set(node.Labels))
+	return r.compiledRuleFor(rule).matches(node.Labels)
 }
 
 // updateRuleCache updates the rule cache
@@ -417,13 +882,32 @@ func (r *ReadinessGateController) updateRuleCache(ctx context.Context, rule *rea
 
 	ruleCopy := rule.DeepCopy()
 	r.ruleCache[rule.Name] = ruleCopy
+
+	// Compile the rule once per rule update rather than per node event;
+	// problems are logged here and reported in the rule status
+	if r.compiledRules == nil {
+		r.compiledRules = make(map[string]*compiledRule)
+	}
+	compiled := compileRule(ruleCopy)
+	if compiled.selectorErr != nil {
+		log.Error(compiled.selectorErr, "Invalid node selector for rule", "rule", rule.Name)
+	}
+	for _, program := range compiled.programs {
+		if program.err != nil {
+			log.Error(program.err, "Invalid expression in rule", "rule", rule.Name, "expression", program.name)
+		}
+	}
+	r.compiledRules[rule.Name] = compiled
+
 	log.V(4).Info("Updated rule cache",
 		"rule", rule.Name,
 		"totalRules", len(r.ruleCache),
 		"resourceVersion", ruleCopy.ResourceVersion)
 }
 
-// getCachedRule retrieves a rule from cache
+// getCachedRule retrieves a copy of a rule from cache. Node workers update
+// the failing node sample of cached rules, so it is copied under
+// failingNodesMutex.
 func (r *ReadinessGateController) getCachedRule(ruleName string) *readinessv1alpha1.NodeReadinessGateRule {
 	r.ruleCacheMutex.RLock()
 	defer r.ruleCacheMutex.RUnlock()
@@ -432,6 +916,9 @@ func (r *ReadinessGateController) getCachedRule(ruleName string) *readinessv1alp
 	if !exists {
 		return nil
 	}
+
+	r.failingNodesMutex.Lock()
+	defer r.failingNodesMutex.Unlock()
 	return rule.DeepCopy()
 }
 
@@ -442,7 +929,19 @@ func (r *ReadinessGateController) removeRuleFromCache(ctx context.Context, ruleN
 	defer r.ruleCacheMutex.Unlock()
 
 	delete(r.ruleCache, ruleName)
+	delete(r.compiledRules, ruleName)
 	log.Info("Removed rule from cache", "rule", ruleName, "totalRules", len(r.ruleCache))
+
+	r.failingSinceMutex.Lock()
+	delete(r.failingSince, ruleName)
+	r.failingSinceMutex.Unlock()
+
+	r.resetNodeOutcomes(ruleName)
+	r.forgetRuleNodes(ruleName)
+	r.forgetTaintBreaker(ruleName)
+	r.forgetRollout(ruleName)
+	r.forgetCanary(ruleName)
+	r.forgetDryRunPlan(ruleName)
 }
 
 // updateRuleStatus updates the status of a NodeReadinessGateRule
@@ -451,8 +950,8 @@ func (r *ReadinessGateController) updateRuleStatus(ctx context.Context, rule *re
 
 	log.V(1).Info("Updating rule status",
 		"rule", rule.Name,
-		"nodeEvaluations", len(rule.Status.NodeEvaluations),
-		"appliedNodes", len(rule.Status.AppliedNodes))
+		"matchedNodes", rule.Status.MatchedNodes,
+		"failingNodes", len(rule.Status.FailingNodes))
 
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		latestRule := &readinessv1alpha1.NodeReadinessGateRule{}
@@ -462,78 +961,78 @@ func (r *ReadinessGateController) updateRuleStatus(ctx context.Context, rule *re
 
 		// Merge our status updates into fresh version
 		// This ensures we're updating based on the latest resourceVersion
-		latestRule.Status.NodeEvaluations = rule.Status.NodeEvaluations
-		latestRule.Status.AppliedNodes = rule.Status.AppliedNodes
-		latestRule.Status.FailedNodes = rule.Status.FailedNodes
+		latestRule.Status.FailingNodes = r.failingNodesSnapshot(rule)
 		latestRule.Status.ObservedGeneration = rule.Status.ObservedGeneration
 		latestRule.Status.DryRunResults = rule.Status.DryRunResults
+		latestRule.Status.AppliedTaints = rule.Status.AppliedTaints
+		latestRule.Status.Rollout = rule.Status.Rollout
+		latestRule.Status.Canary = rule.Status.Canary
+		r.setRuleSummary(latestRule)
 
 		if err := r.Status().Update(ctx, latestRule); err != nil {
+			recordStatusWriteError(err)
 			log.V(1).Info("Status update conflict, will retry",
 				"rule", rule.Name,
 				"error", err.Error())
 			return err
 		}
 
+		ruleStatusWrites.WithLabelValues("success").Inc()
 		log.V(1).Info("Successfully updated rule status", "rule", rule.Name)
 		return nil
 	})
 }
 
-// processDryRun processes dry run for a rule
-func (r *ReadinessGateController) processDryRun(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) error {
-	nodeList := &corev1.NodeList{}
-	if err := r.List(ctx, nodeList); err != nil {
-		return err
-	}
-
-	var affectedNodes, taintsToAdd, taintsToRemove, riskyOps int
-	var summaryParts []string
-
-	for _, node := range nodeList.Items {
-		if !r.ruleAppliesTo(ctx, rule, &node) {
-			continue
-		}
+// ruleSimulation counts the changes a rule would make to its nodes
+type ruleSimulation struct {
+	affectedNodes, gatedNodes   int
+	taintsToAdd, taintsToRemove int
+	missingNodes, unknownNodes  int
+	riskyOps                    int
 
-		affectedNodes++
+	// nodes holds the change to each node, by node name
+	nodes map[string]nodeSimulation
+}
 
-		// Simulate rule evaluation
-		allConditionsSatisfied := true
-		missingConditions := 0
+// simulateRule evaluates a rule against the given nodes without changing
+// them, recording each node's current state as its outcome
+func (r *ReadinessGateController) simulateRule(rule *readinessv1alpha1.NodeReadinessGateRule, nodes []corev1.Node) ruleSimulation {
+	var sim ruleSimulation
 
-		for _, condReq := range rule.Spec.Conditions {
-			currentStatus := r.getConditionStatus(&node, condReq.Type)
-			if currentStatus == corev1.ConditionUnknown {
-				missingConditions++
-			}
-			if currentStatus != condReq.RequiredStatus {
-				allConditionsSatisfied = false
-			}
-		}
+	for _, node := range nodes {
+		nodeSim := r.simulateNode(rule, &node)
+		r.recordNodeOutcome(rule.Name, node.Name, nodeOutcome{satisfied: nodeSim.satisfied, gated: nodeSim.gated})
+		sim.add(node.Name, nodeSim)
+	}
 
-		shouldRemoveTaint := allConditionsSatisfied
-		currentlyHasTaint := r.hasTaintBySpec(&node, rule.Spec.Taint)
+	return sim
+}
 
-		if shouldRemoveTaint && currentlyHasTaint {
-			taintsToRemove++
-		} else if !shouldRemoveTaint && !currentlyHasTaint {
-			taintsToAdd++
-		}
+// processDryRun reports the changes a rule would make to the given nodes
+// without applying them
+func (r *ReadinessGateController) processDryRun(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, nodes []corev1.Node) {
+	r.resetNodeOutcomes(rule.Name)
+	r.recordDryRunPlan(rule.Name, r.simulateRule(rule, nodes))
+	r.markNodeOutcomesRebuilt(rule.Name)
+	r.reportDryRun(ctx, rule)
+}
 
-		if missingConditions > 0 {
-			riskyOps++
-		}
-	}
+// dryRunResults reports the changes counted by a rule simulation
+func dryRunResults(sim ruleSimulation) *readinessv1alpha1.DryRunResults {
 
 	// Build summary
-	if taintsToAdd > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("would add %d taints", taintsToAdd))
+	var summaryParts []string
+	if sim.taintsToAdd > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("would add %d taints", sim.taintsToAdd))
+	}
+	if sim.taintsToRemove > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("would remove %d taints", sim.taintsToRemove))
 	}
-	if taintsToRemove > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("would remove %d taints", taintsToRemove))
+	if sim.missingNodes > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("%d nodes have missing conditions", sim.missingNodes))
 	}
-	if riskyOps > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("%d nodes have missing conditions", riskyOps))
+	if sim.unknownNodes > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("%d nodes have conditions with Unknown status", sim.unknownNodes))
 	}
 
 	summary := "No changes needed"
@@ -541,47 +1040,53 @@ func (r *ReadinessGateController) processDryRun(ctx context.Context, rule *readi
 		summary = strings.Join(summaryParts, ", ")
 	}
 
-	// Update rule status with dry run results
-	rule.Status.DryRunResults = &readinessv1alpha1.DryRunResults{
-		AffectedNodes:   affectedNodes,
-		TaintsToAdd:     taintsToAdd,
-		TaintsToRemove:  taintsToRemove,
-		RiskyOperations: riskyOps,
-		Summary:         summary,
+	// Only a sample of the planned nodes fits in the rule status
+	plan := sim.plan()
+	if len(plan) > maxDryRunPlanNodes {
+		plan = plan[:maxDryRunPlanNodes]
 	}
 
-	return nil
-}
+	return &readinessv1alpha1.DryRunResults{
+		AffectedNodes:   sim.affectedNodes,
+		TaintsToAdd:     sim.taintsToAdd,
+		TaintsToRemove:  sim.taintsToRemove,
+		RiskyOperations: sim.riskyOps,
 
-// SetGlobalDryRun sets the global dry run mode (emergency off-switch)
-func (r *ReadinessGateController) SetGlobalDryRun(dryRun bool) {
-	r.globalDryRun = dryRun
+		NodesWithMissingConditions: sim.missingNodes,
+		NodesWithUnknownConditions: sim.unknownNodes,
+
+		Summary: summary,
+		Nodes:   plan,
+	}
 }
 
 // cleanupTaintsForRule removes taints managed by this rule from all applicable nodes
 func (r *ReadinessGateController) cleanupTaintsForRule(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule) error {
 	log := ctrl.LoggerFrom(ctx)
 
+	// A rule with an invalid node selector applies to no node; its taints were
+	// removed when the selector changed
+	if r.compiledRuleFor(rule).selectorErr != nil {
+		log.Info("Rule has an invalid node selector, no taints to clean up", "rule", rule.Name)
+		return nil
+	}
+
 	// Get all nodes that this rule applies to
-	nodeList := &corev1.NodeList{}
-	if err := r.List(ctx, nodeList); err != nil {
-		return fmt.Errorf("failed to list nodes: %w", err)
+	nodes, err := r.listRuleNodes(ctx, rule)
+	if err != nil {
+		return err
 	}
 
 	var errors []string
-	for _, node := range nodeList.Items {
-		if !r.ruleAppliesTo(ctx, rule, &node) {
-			continue
-		}
-
-		// Check if node has the taint managed by this rule
-		if r.hasTaintBySpec(&node, rule.Spec.Taint) {
-			log.Info("Removing taint from node during rule cleanup",
+	for _, node := range nodes {
+		// Check if node has any taint or gate action managed by this rule
+		if gated, _ := r.gateApplied(&node, rule); gated || r.hasGateCondition(&node, rule) || hasTaintOwner(&node, rule.Name) {
+			log.Info("Removing taints from node during rule cleanup",
 				"node", node.Name,
 				"rule", rule.Name,
-				"taint", rule.Spec.Taint.Key)
+				"taints", rule.Spec.GetTaints())
 
-			if err := r.removeTaintBySpec(ctx, &node, rule.Spec.Taint); err != nil {
+			if err := r.cleanupGateActions(ctx, &node, rule); err != nil {
 				errors = append(errors, fmt.Sprintf("node %s: %v", node.Name, err))
 			}
 		}
@@ -598,49 +1103,34 @@ func (r *ReadinessGateController) cleanupTaintsForRule(ctx context.Context, rule
 func (r *ReadinessGateController) cleanupNodesAfterSelectorChange(ctx context.Context, oldRule, newRule *readinessv1alpha1.NodeReadinessGateRule) error {
 	log := ctrl.LoggerFrom(ctx)
 
-	// Get all nodes
-	nodeList := &corev1.NodeList{}
-	if err := r.List(ctx, nodeList); err != nil {
-		return fmt.Errorf("failed to list nodes: %w", err)
-	}
-
-	// Build old selector
-	var oldSelector labels.Selector
-	var err error
-	if oldRule.Spec.NodeSelector != nil {
-		oldSelector, err = metav1.LabelSelectorAsSelector(oldRule.Spec.NodeSelector)
-		if err != nil {
-			return fmt.Errorf("failed to parse old node selector: %w", err)
-		}
+	// Get the nodes that matched the old selector
+	oldNodes, err := r.previousRuleNodes(ctx, oldRule)
+	if err != nil {
+		return fmt.Errorf("failed to find nodes matching old node selector: %w", err)
 	}
 
 	// Clean up nodes that matched old selector but not new selector
 	var errors []string
-	for _, node := range nodeList.Items {
-		// Check if node matched old selector
-		matchedOld := false
-		if oldSelector == nil {
-			// nil selector matches all nodes
-			matchedOld = true
-		} else {
-			matchedOld = oldSelector.Matches(labels.Set(node.Labels))
-		}
-
+	for _, node := range oldNodes {
 		// Check if node matches new selector (use newRule for current evaluation)
 		matchesNew := r.ruleAppliesTo(ctx, newRule, &node)
 
 		// If node matched old but not new, clean up the taint
-		if matchedOld && !matchesNew {
-			if r.hasTaintBySpec(&node, newRule.Spec.Taint) {
-				log.Info("Removing taint from node that no longer matches selector",
+		if !matchesNew {
+			if gated, _ := r.gateApplied(&node, newRule); gated || r.hasGateCondition(&node, newRule) || hasTaintOwner(&node, newRule.Name) {
+				log.Info("Removing taints from node that no longer matches selector",
 					"node", node.Name,
 					"rule", newRule.Name,
-					"taint", newRule.Spec.Taint.Key)
+					"taints", newRule.Spec.GetTaints())
 
-				if err := r.removeTaintBySpec(ctx, &node, newRule.Spec.Taint); err != nil {
+				if err := r.cleanupGateActions(ctx, &node, newRule); err != nil {
 					errors = append(errors, fmt.Sprintf("node %s: %v", node.Name, err))
 				}
 			}
+
+			if err := r.removeRuleEvaluation(ctx, node.Name, newRule.Name); err != nil {
+				errors = append(errors, fmt.Sprintf("node %s: %v", node.Name, err))
+			}
 		}
 	}
 
@@ -651,6 +1141,121 @@ func (r *ReadinessGateController) cleanupNodesAfterSelectorChange(ctx context.Co
 	return nil
 }
 
+// taintMigration pairs a taint of the previous rule spec with its
+// replacement. A nil replacement means the taint is no longer managed.
+type taintMigration struct {
+	from readinessv1alpha1.TaintSpec
+	to   *readinessv1alpha1.TaintSpec
+}
+
+// taintMigrations returns the taints that changed between two versions of a
+// rule. Taints are paired by key and effect first, so that value changes are
+// found, then by key alone for effect changes. Taints whose key changed are
+// paired with the remaining new taints in order, and are removed when there
+// is nothing left to pair them with.
+func taintMigrations(previous, current []readinessv1alpha1.TaintSpec) []taintMigration {
+	var migrations []taintMigration
+	matchedPrevious := make([]bool, len(previous))
+	matchedCurrent := make([]bool, len(current))
+
+	pair := func(matches func(from, to readinessv1alpha1.TaintSpec) bool) {
+		for i := range current {
+			if matchedCurrent[i] {
+				continue
+			}
+			for j, from := range previous {
+				if matchedPrevious[j] || !matches(from, current[i]) {
+					continue
+				}
+				if from != current[i] {
+					migrations = append(migrations, taintMigration{from: from, to: &current[i]})
+				}
+				matchedPrevious[j], matchedCurrent[i] = true, true
+				break
+			}
+		}
+	}
+
+	pair(func(from, to readinessv1alpha1.TaintSpec) bool {
+		return from.Key == to.Key && from.Effect == to.Effect
+	})
+	pair(func(from, to readinessv1alpha1.TaintSpec) bool {
+		return from.Key == to.Key
+	})
+	pair(func(from, to readinessv1alpha1.TaintSpec) bool {
+		return true
+	})
+
+	for j, from := range previous {
+		if !matchedPrevious[j] {
+			migrations = append(migrations, taintMigration{from: from})
+		}
+	}
+
+	return migrations
+}
+
+// appliedTaints returns the taints the rule last enforced, as recorded in its
+// status or, for rules enforced before the status recorded them, by the
+// cached version of the rule
+func appliedTaints(rule, cachedRule *readinessv1alpha1.NodeReadinessGateRule) ([]readinessv1alpha1.TaintSpec, bool) {
+	if rule.Status.AppliedTaints != nil {
+		return rule.Status.AppliedTaints, true
+	}
+	if cachedRule != nil {
+		return cachedRule.Spec.GetTaints(), true
+	}
+	return nil, false
+}
+
+// migrateTaintsAfterSpecChange replaces or removes outdated taints on the
+// nodes the previous version of the rule applied to. Without a cached
+// previous version, e.g. after a restart, every node is checked; only taints
+// owned by the rule are touched.
+func (r *ReadinessGateController) migrateTaintsAfterSpecChange(
+	ctx context.Context,
+	rule, cachedRule *readinessv1alpha1.NodeReadinessGateRule,
+	migrations []taintMigration,
+) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	oldRule := cachedRule
+	var nodes []corev1.Node
+	var err error
+	if oldRule != nil {
+		nodes, err = r.previousRuleNodes(ctx, oldRule)
+	} else {
+		oldRule = rule
+		nodes, err = r.listNodesMatching(ctx, labels.Everything())
+	}
+	if err != nil {
+		return err
+	}
+
+	var errors []string
+	for _, node := range nodes {
+		for _, migration := range migrations {
+			if r.hasTaintBySpec(&node, migration.from) && ownsTaint(&node, oldRule, migration.from) {
+				log.Info("Migrating taint to new spec",
+					"node", node.Name,
+					"rule", oldRule.Name,
+					"from", migration.from,
+					"to", migration.to)
+			}
+		}
+
+		if err := r.replaceTaintsBySpec(ctx, &node, oldRule, migrations); err != nil {
+			errors = append(errors, fmt.Sprintf("node %s: %v", node.Name, err))
+		}
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to migrate taints on some nodes: %s", strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
 // nodeSelectorChanged checks if nodeSelector has changed
 func nodeSelectorChanged(current, previous *metav1.LabelSelector) bool {
 	// Both nil - no change
@@ -742,7 +1347,7 @@ func removeFinalizer(rule *readinessv1alpha1.NodeReadinessGateRule, finalizer st
 func (r *RuleReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	c, err := controller.New("nodereadiness-controller", mgr, controller.Options{
 		Reconciler:              r,
-		MaxConcurrentReconciles: 1,
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 	})
 	if err != nil {
 		return err
@@ -760,5 +1365,14 @@ func (r *RuleReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager)
 		return err
 	}
 
+	// Requeue rules on demand, e.g. when the global dry run is toggled
+	if r.Controller.ruleEvents != nil {
+		err = c.Watch(source.Channel(r.Controller.ruleEvents,
+			&handler.TypedEnqueueRequestForObject[*readinessv1alpha1.NodeReadinessGateRule]{}))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
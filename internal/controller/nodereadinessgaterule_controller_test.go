@@ -18,16 +18,30 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	nodereadinessiov1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
@@ -80,7 +94,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: nodereadinessiov1alpha1.TaintSpec{
+					Taint: &nodereadinessiov1alpha1.TaintSpec{
 						Key:    "test-taint",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -117,7 +131,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: nodereadinessiov1alpha1.TaintSpec{
+					Taint: &nodereadinessiov1alpha1.TaintSpec{
 						Key:    "test-taint",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -150,6 +164,54 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 			}).Should(BeTrue())
 		})
 
+		It("should report an invalid node selector in status instead of failing", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "invalid-selector-rule",
+				},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taint: &nodereadinessiov1alpha1.TaintSpec{
+						Key:    "test-taint",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+					NodeSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "pool", Operator: "Bogus"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+
+			result, err := ruleReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: rule.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule)).To(Succeed())
+			degraded := meta.FindStatusCondition(updatedRule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("InvalidNodeSelector"))
+			Expect(meta.IsStatusConditionFalse(updatedRule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionReady)).To(BeTrue())
+
+			// The rule can still be deleted
+			Expect(k8sClient.Delete(ctx, rule)).To(Succeed())
+			_, err = ruleReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: rule.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule))
+			}).Should(BeTrue())
+		})
+
 		It("should immediately process existing nodes on rule creation", func() {
 			// Create a test node first
 			testNode := &corev1.Node{
@@ -177,7 +239,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
 						{Type: "TestCondition", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: nodereadinessiov1alpha1.TaintSpec{
+					Taint: &nodereadinessiov1alpha1.TaintSpec{
 						Key:    "immediate-test-taint",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -213,15 +275,19 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 				return false
 			}, time.Second*5).Should(BeTrue())
 
-			// Verify rule status includes the node
-			Eventually(func() []string {
-				updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			// Verify rule status counts the node and the node reports the evaluation
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Eventually(func() int32 {
 				err := k8sClient.Get(ctx, types.NamespacedName{Name: "immediate-test-rule"}, updatedRule)
 				if err != nil {
-					return nil
+					return 0
 				}
-				return updatedRule.Status.AppliedNodes
-			}, time.Second*5).Should(ContainElement("immediate-test-node"))
+				return updatedRule.Status.MatchedNodes
+			}, time.Second*5).Should(Equal(int32(1)))
+			Expect(updatedRule.Status.TaintedNodes).To(Equal(int32(1)))
+			Expect(updatedRule.Status.ReadyNodes).To(BeZero())
+			Expect(meta.IsStatusConditionFalse(updatedRule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionReady)).To(BeTrue())
+			Expect(fetchRuleEvaluation(ctx, "immediate-test-node", "immediate-test-rule")).NotTo(BeNil())
 		})
 
 		It("should handle dry run mode", func() {
@@ -233,7 +299,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: nodereadinessiov1alpha1.TaintSpec{
+					Taint: &nodereadinessiov1alpha1.TaintSpec{
 						Key:    "dry-run-taint",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -302,7 +368,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: nodereadinessiov1alpha1.TaintSpec{
+					Taint: &nodereadinessiov1alpha1.TaintSpec{
 						Key:    "node-test-taint",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -353,6 +419,218 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 			Expect(status).To(Equal(corev1.ConditionUnknown))
 		})
 
+		It("should distinguish missing conditions from Unknown conditions", func() {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: "AgentReady", Status: corev1.ConditionUnknown},
+					},
+				},
+			}
+
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
+						{Type: "AgentReady", RequiredStatus: corev1.ConditionTrue},
+						{Type: "NotReported", RequiredStatus: corev1.ConditionTrue},
+					},
+				},
+			}
+
+			eval := readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeFalse())
+			Expect(eval.conditionResults[0].CurrentStatus).To(Equal(corev1.ConditionUnknown))
+			Expect(eval.conditionResults[0].Missing).To(BeFalse())
+			Expect(eval.conditionResults[1].Missing).To(BeTrue())
+			Expect(eval.conditionResults[1].Satisfied).To(BeFalse())
+
+			// TreatAsSatisfied only applies to missing conditions, not Unknown ones
+			rule.Spec.Conditions[0].MissingPolicy = nodereadinessiov1alpha1.MissingPolicyTreatAsSatisfied
+			rule.Spec.Conditions[1].MissingPolicy = nodereadinessiov1alpha1.MissingPolicyTreatAsSatisfied
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.conditionResults[0].Satisfied).To(BeFalse())
+			Expect(eval.conditionResults[1].Satisfied).To(BeTrue())
+
+			// TreatAsSatisfiedAfter waits until the node is old enough
+			rule.Spec.Conditions[1].MissingPolicy = nodereadinessiov1alpha1.MissingPolicyTreatAsSatisfiedAfter
+			rule.Spec.Conditions[1].MissingTimeout = &metav1.Duration{Duration: 5 * time.Minute}
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.conditionResults[1].Satisfied).To(BeFalse())
+			Expect(eval.requeueAfter).To(BeNumerically("~", 4*time.Minute, 5*time.Second))
+
+			node.CreationTimestamp = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.conditionResults[1].Satisfied).To(BeTrue())
+			Expect(eval.requeueAfter).To(BeZero())
+		})
+
+		It("should treat conditions older than maxAge as stale", func() {
+			node := &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:              "CNIReady",
+							Status:            corev1.ConditionTrue,
+							LastHeartbeatTime: metav1.NewTime(time.Now().Add(-30 * time.Second)),
+						},
+					},
+				},
+			}
+
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
+						{Type: "CNIReady", RequiredStatus: corev1.ConditionTrue, MaxAge: &metav1.Duration{Duration: time.Minute}},
+					},
+				},
+			}
+
+			// Fresh condition is satisfied and re-checked when it expires
+			eval := readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeTrue())
+			Expect(eval.conditionResults[0].Stale).To(BeFalse())
+			Expect(eval.requeueAfter).To(BeNumerically("~", 30*time.Second, 5*time.Second))
+
+			// Old heartbeat makes the condition stale
+			node.Status.Conditions[0].LastHeartbeatTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeFalse())
+			Expect(eval.conditionResults[0].Stale).To(BeTrue())
+			Expect(eval.requeueAfter).To(Equal(time.Minute))
+
+			// Without a heartbeat, lastTransitionTime is used
+			node.Status.Conditions[0].LastHeartbeatTime = metav1.Time{}
+			node.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now())
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeTrue())
+		})
+
+		It("should evaluate condition groups", func() {
+			node := &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: "CalicoReady", Status: corev1.ConditionFalse},
+						{Type: "CiliumReady", Status: corev1.ConditionTrue},
+						{Type: "StorageReady", Status: corev1.ConditionTrue},
+						{Type: "GPU0Healthy", Status: corev1.ConditionTrue},
+						{Type: "GPU1Healthy", Status: corev1.ConditionFalse},
+						{Type: "GPU2Healthy", Status: corev1.ConditionTrue},
+					},
+				},
+			}
+
+			// (CalicoReady OR CiliumReady) AND StorageReady AND at least 2 of 3 GPUs healthy
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
+						{Type: "StorageReady", RequiredStatus: corev1.ConditionTrue},
+					},
+					ConditionGroups: []nodereadinessiov1alpha1.ConditionGroup{
+						{
+							Name:     "cni",
+							Operator: nodereadinessiov1alpha1.ConditionGroupOperatorAny,
+							Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
+								{Type: "CalicoReady", RequiredStatus: corev1.ConditionTrue},
+								{Type: "CiliumReady", RequiredStatus: corev1.ConditionTrue},
+							},
+						},
+						{
+							Name:     "accelerators",
+							Operator: nodereadinessiov1alpha1.ConditionGroupOperatorAll,
+							Groups: []nodereadinessiov1alpha1.ConditionSubGroup{
+								{
+									Name:         "gpu",
+									Operator:     nodereadinessiov1alpha1.ConditionGroupOperatorAtLeast,
+									MinSatisfied: 2,
+									Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
+										{Type: "GPU0Healthy", RequiredStatus: corev1.ConditionTrue},
+										{Type: "GPU1Healthy", RequiredStatus: corev1.ConditionTrue},
+										{Type: "GPU2Healthy", RequiredStatus: corev1.ConditionTrue},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			eval := readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeTrue())
+			Expect(eval.conditionResults).To(HaveLen(6))
+			Expect(eval.groupResults).To(ConsistOf(
+				nodereadinessiov1alpha1.ConditionGroupResult{
+					Name: "cni", Operator: nodereadinessiov1alpha1.ConditionGroupOperatorAny,
+					SatisfiedCount: 1, Total: 2, Satisfied: true,
+				},
+				nodereadinessiov1alpha1.ConditionGroupResult{
+					Name: "accelerators/gpu", Operator: nodereadinessiov1alpha1.ConditionGroupOperatorAtLeast,
+					SatisfiedCount: 2, Total: 3, Satisfied: true,
+				},
+				nodereadinessiov1alpha1.ConditionGroupResult{
+					Name: "accelerators", Operator: nodereadinessiov1alpha1.ConditionGroupOperatorAll,
+					SatisfiedCount: 1, Total: 1, Satisfied: true,
+				},
+			))
+
+			// A second unhealthy GPU fails the AtLeast group and the rule
+			node.Status.Conditions[5].Status = corev1.ConditionFalse
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeFalse())
+
+			// Losing both CNIs fails the Any group
+			node.Status.Conditions[5].Status = corev1.ConditionTrue
+			node.Status.Conditions[1].Status = corev1.ConditionFalse
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeFalse())
+		})
+
+		It("should evaluate CEL expressions against the node", func() {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"accelerator": "nvidia"},
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("2"),
+					},
+				},
+			}
+
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "expression-rule", Generation: 1},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Expressions: []nodereadinessiov1alpha1.ExpressionRequirement{
+						{Name: "has-gpu", Expression: "node.status.allocatable['nvidia.com/gpu'] > 0"},
+						{Name: "labelled", Expression: "'accelerator' in node.metadata.labels"},
+					},
+				},
+			}
+
+			// Programs are compiled once when the rule is cached
+			readinessController.updateRuleCache(ctx, rule)
+			Expect(readinessController.compiledRules).To(HaveKey("expression-rule"))
+
+			eval := readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeTrue())
+			Expect(eval.expressionResults).To(ConsistOf(
+				nodereadinessiov1alpha1.ExpressionEvaluationResult{Name: "has-gpu", Satisfied: true},
+				nodereadinessiov1alpha1.ExpressionEvaluationResult{Name: "labelled", Satisfied: true},
+			))
+
+			// Evaluation errors leave the expression unsatisfied
+			delete(node.Status.Allocatable, "nvidia.com/gpu")
+			eval = readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeFalse())
+			Expect(eval.expressionResults[0].Satisfied).To(BeFalse())
+			Expect(eval.expressionResults[0].Error).NotTo(BeEmpty())
+
+			readinessController.removeRuleFromCache(ctx, rule.Name)
+			Expect(readinessController.compiledRules).NotTo(HaveKey("expression-rule"))
+		})
+
 		It("should detect taints correctly", func() {
 			node := &corev1.Node{
 				Spec: corev1.NodeSpec{
@@ -381,6 +659,33 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 			Expect(hasTaint).To(BeFalse())
 		})
 
+		It("should pair changed taints by key", func() {
+			previous := []nodereadinessiov1alpha1.TaintSpec{
+				{Key: "a", Effect: corev1.TaintEffectNoSchedule, Value: "pending"},
+				{Key: "b", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "c", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "d", Effect: corev1.TaintEffectNoSchedule},
+			}
+			current := []nodereadinessiov1alpha1.TaintSpec{
+				{Key: "a", Effect: corev1.TaintEffectNoSchedule, Value: "cni-pending"},
+				{Key: "b", Effect: corev1.TaintEffectNoExecute},
+				{Key: "c", Effect: corev1.TaintEffectNoSchedule},
+			}
+
+			Expect(taintMigrations(previous, current)).To(ConsistOf(
+				taintMigration{from: previous[0], to: &current[0]},
+				taintMigration{from: previous[1], to: &current[1]},
+				taintMigration{from: previous[3]},
+			))
+			Expect(taintMigrations(current, current)).To(BeEmpty())
+
+			// A renamed key is swapped for the new one
+			renamed := []nodereadinessiov1alpha1.TaintSpec{{Key: "z", Effect: corev1.TaintEffectNoSchedule}}
+			Expect(taintMigrations(previous[2:3], renamed)).To(ConsistOf(
+				taintMigration{from: previous[2], to: &renamed[0]},
+			))
+		})
+
 		It("should check rule applicability correctly", func() {
 			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
 				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
@@ -425,13 +730,98 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 			Expect(applies).To(BeTrue())
 		})
 
+		It("should pick a selector requirement the node label index can answer", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"pool": "gpu"}}}
+			Expect(nodeLabelIndexValues(node)).To(ConsistOf("pool", "pool=gpu"))
+
+			selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "gpu"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			value, ok := selectorIndexValue(selector)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("pool=gpu"))
+
+			selector, err = metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "zone", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a"}},
+					{Key: "pool", Operator: metav1.LabelSelectorOpExists},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			value, ok = selectorIndexValue(selector)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("pool"))
+
+			_, ok = selectorIndexValue(labels.Everything())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should keep the nodes of each rule up to date as node labels change", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{ObjectMeta: metav1.ObjectMeta{Name: "tracked-rule"}}
+			otherRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{ObjectMeta: metav1.ObjectMeta{Name: "other-rule"}}
+
+			// Nodes are only tracked once the rule has been processed
+			readinessController.updateRuleNodes("node-a", []*nodereadinessiov1alpha1.NodeReadinessGateRule{rule})
+			_, known := readinessController.knownRuleNodes(rule.Name)
+			Expect(known).To(BeFalse())
+
+			readinessController.setRuleNodes(rule.Name, []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+			})
+
+			// node-a lost the label selected by the rule, node-c gained it
+			readinessController.updateRuleNodes("node-a", []*nodereadinessiov1alpha1.NodeReadinessGateRule{otherRule})
+			readinessController.updateRuleNodes("node-c", []*nodereadinessiov1alpha1.NodeReadinessGateRule{rule})
+			nodeNames, known := readinessController.knownRuleNodes(rule.Name)
+			Expect(known).To(BeTrue())
+			Expect(nodeNames).To(Equal([]string{"node-b", "node-c"}))
+
+			readinessController.forgetNode("node-b")
+			nodeNames, _ = readinessController.knownRuleNodes(rule.Name)
+			Expect(nodeNames).To(Equal([]string{"node-c"}))
+
+			readinessController.removeRuleFromCache(ctx, rule.Name)
+			_, known = readinessController.knownRuleNodes(rule.Name)
+			Expect(known).To(BeFalse())
+		})
+
+		It("should list only the nodes matching a rule's selector", func() {
+			for _, node := range []*corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "indexed-gpu", Labels: map[string]string{"indexed-pool": "gpu"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "indexed-cpu", Labels: map[string]string{"indexed-pool": "cpu"}}},
+			} {
+				Expect(k8sClient.Create(ctx, node)).To(Succeed())
+				defer k8sClient.Delete(ctx, node)
+			}
+
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "indexed-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"indexed-pool": "gpu"}},
+				},
+			}
+			nodes, err := readinessController.listRuleNodes(ctx, rule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(1))
+			Expect(nodes[0].Name).To(Equal("indexed-gpu"))
+		})
+
 		It("should handle bootstrap completion tracking", func() {
 			nodeName := "bootstrap-test-node"
-			ruleName := "bootstrap-test-rule"
-
-			// Initially not completed
-			completed := readinessController.isBootstrapCompleted(nodeName, ruleName)
-			Expect(completed).To(BeFalse())
+			// Rule names longer than an annotation name allows are tracked by UID
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "bootstrap-test-rule-with-a-name-longer-than-an-annotation-name-may-be",
+					UID:  "bootstrap-test-rule-uid",
+				},
+			}
+			bootstrapCompleted := func() bool {
+				node := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: nodeName}, node)).To(Succeed())
+				return isBootstrapCompleted(node, rule)
+			}
 
 			// Create a node for testing
 			node := &corev1.Node{
@@ -442,13 +832,296 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 			Expect(k8sClient.Create(ctx, node)).To(Succeed())
 			defer k8sClient.Delete(ctx, node)
 
+			// Initially not completed
+			Expect(bootstrapCompleted()).To(BeFalse())
+
 			// Mark as completed
-			readinessController.markBootstrapCompleted(ctx, nodeName, ruleName)
+			readinessController.markBootstrapCompleted(ctx, nodeName, rule)
 
 			// Should now be completed
-			Eventually(func() bool {
-				return readinessController.isBootstrapCompleted(nodeName, ruleName)
-			}).Should(BeTrue())
+			Eventually(bootstrapCompleted).Should(BeTrue())
+
+			updatedNode := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: nodeName}, updatedNode)).To(Succeed())
+			completion := getBootstrapCompletions(updatedNode)[rule.UID]
+			Expect(completion.Rule).To(Equal(rule.Name))
+			Expect(completion.CompletedAt.IsZero()).To(BeFalse())
+
+			// Clearing completion re-arms the rule
+			Expect(readinessController.clearBootstrapCompleted(ctx, nodeName, rule)).To(Succeed())
+			Expect(bootstrapCompleted()).To(BeFalse())
+		})
+
+		It("should migrate legacy bootstrap completion annotations", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy-rule", UID: "legacy-rule-uid"},
+			}
+			readinessController.ruleCache[rule.Name] = rule
+
+			// A rule that exists but has not been cached yet
+			uncachedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "not-yet-cached-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "legacy-taint", Effect: corev1.TaintEffectNoSchedule},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeBootstrapOnly,
+				},
+			}
+			Expect(k8sClient.Create(ctx, uncachedRule)).To(Succeed())
+			defer k8sClient.Delete(ctx, uncachedRule)
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "legacy-bootstrap-node",
+					Annotations: map[string]string{
+						legacyBootstrapAnnotationKey(rule.Name):         "true",
+						legacyBootstrapAnnotationKey(uncachedRule.Name): "true",
+						legacyBootstrapAnnotationKey("deleted-rule"):    "true",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+			defer k8sClient.Delete(ctx, node)
+
+			// Legacy completions are honoured before migration
+			Expect(isBootstrapCompleted(node, rule)).To(BeTrue())
+
+			Expect(readinessController.migrateBootstrapAnnotations(ctx, node)).To(Succeed())
+			Expect(node.Annotations).NotTo(HaveKey(legacyBootstrapAnnotationKey(rule.Name)))
+			Expect(node.Annotations).To(HaveKey(legacyBootstrapAnnotationKey(uncachedRule.Name)))
+			Expect(node.Annotations).NotTo(HaveKey(legacyBootstrapAnnotationKey("deleted-rule")))
+			Expect(getBootstrapCompletions(node)).To(HaveKey(rule.UID))
+			Expect(isBootstrapCompleted(node, rule)).To(BeTrue())
+
+			// Deleting the rule garbage collects its completion record
+			Expect(readinessController.cleanupBootstrapStateForRule(ctx, rule)).To(Succeed())
+			updatedNode := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: node.Name}, updatedNode)).To(Succeed())
+			Expect(isBootstrapCompleted(updatedNode, rule)).To(BeFalse())
+			Expect(updatedNode.Annotations).NotTo(HaveKey(bootstrapCompletedAnnotation))
+		})
+
+		It("should cap the failing node sample", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			for i := range maxFailingNodes + 5 {
+				readinessController.recordNodeFailure(rule, fmt.Sprintf("node-%d", i), "EvaluationError", "test")
+			}
+			Expect(rule.Status.FailingNodes).To(HaveLen(maxFailingNodes))
+			Expect(rule.Status.FailingNodes[maxFailingNodes-1].NodeName).To(Equal(fmt.Sprintf("node-%d", maxFailingNodes+4)))
+
+			readinessController.clearNodeFailure(rule, fmt.Sprintf("node-%d", maxFailingNodes+4))
+			Expect(rule.Status.FailingNodes).To(HaveLen(maxFailingNodes - 1))
+		})
+
+		It("should evaluate nodes with bounded parallelism", func() {
+			DeferCleanup(func(workers int) { readinessController.nodeWorkers = workers }, readinessController.nodeWorkers)
+			readinessController.SetNodeWorkers(3)
+			nodes := make([]corev1.Node, 20)
+			for i := range nodes {
+				nodes[i].Name = fmt.Sprintf("node-%d", i)
+			}
+
+			var mu sync.Mutex
+			var running, maxRunning int
+			processed := map[string]bool{}
+			Expect(readinessController.forEachNode(ctx, nodes, func(node *corev1.Node) {
+				mu.Lock()
+				running++
+				maxRunning = max(maxRunning, running)
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				processed[node.Name] = true
+				mu.Unlock()
+			})).To(Succeed())
+
+			Expect(processed).To(HaveLen(len(nodes)))
+			Expect(maxRunning).To(BeNumerically("<=", 3))
+		})
+
+		It("should track node failures from concurrent workers", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			nodes := make([]corev1.Node, maxFailingNodes)
+			for i := range nodes {
+				nodes[i].Name = fmt.Sprintf("node-%d", i)
+			}
+
+			Expect(readinessController.forEachNode(ctx, nodes, func(node *corev1.Node) {
+				readinessController.recordNodeFailure(rule, node.Name, "EvaluationError", "test")
+			})).To(Succeed())
+			Expect(readinessController.failingNodesSnapshot(rule)).To(HaveLen(maxFailingNodes))
+
+			Expect(readinessController.forEachNode(ctx, nodes, func(node *corev1.Node) {
+				readinessController.clearNodeFailure(rule, node.Name)
+			})).To(Succeed())
+			Expect(rule.Status.FailingNodes).To(BeEmpty())
+		})
+
+		It("should summarize node outcomes in rule status", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "summary-rule", Generation: 3},
+			}
+			readinessController.recordNodeOutcome(rule.Name, "ready-node", nodeOutcome{satisfied: true})
+			readinessController.recordNodeOutcome(rule.Name, "gated-node", nodeOutcome{gated: true})
+			readinessController.recordNodeOutcome(rule.Name, "pending-node", nodeOutcome{satisfied: true, gated: true, pending: true})
+			readinessController.markNodeOutcomeFailed(rule.Name, "failed-node")
+			readinessController.markNodeOutcomesRebuilt(rule.Name)
+
+			readinessController.setRuleSummary(rule)
+			Expect(rule.Status.MatchedNodes).To(Equal(int32(4)))
+			Expect(rule.Status.ReadyNodes).To(Equal(int32(1)))
+			Expect(rule.Status.TaintedNodes).To(Equal(int32(2)))
+			Expect(rule.Status.FailedNodes).To(Equal(int32(1)))
+
+			ready := meta.FindStatusCondition(rule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.ObservedGeneration).To(Equal(int64(3)))
+			Expect(meta.IsStatusConditionTrue(rule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionProgressing)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(rule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(rule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDryRun)).To(BeTrue())
+
+			// Nodes that are deleted or stop matching drop out of the counters
+			readinessController.forgetNode("failed-node")
+			readinessController.retainNodeOutcomes("gated-node", nil)
+			readinessController.retainNodeOutcomes("pending-node", nil)
+			rule.Spec.DryRun = true
+			readinessController.setRuleSummary(rule)
+			Expect(rule.Status.MatchedNodes).To(Equal(int32(1)))
+			Expect(meta.IsStatusConditionTrue(rule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(rule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(rule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDryRun)).To(BeTrue())
+		})
+
+		It("should keep the persisted summary until node outcomes are rebuilt", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "restarted-summary-rule"},
+				Status: nodereadinessiov1alpha1.NodeReadinessGateRuleStatus{
+					MatchedNodes: 100,
+					ReadyNodes:   90,
+					TaintedNodes: 10,
+				},
+			}
+			DeferCleanup(readinessController.resetNodeOutcomes, rule.Name)
+
+			// Only one node was evaluated since the restart
+			readinessController.recordNodeOutcome(rule.Name, "ready-node", nodeOutcome{satisfied: true})
+			readinessController.setRuleSummary(rule)
+			Expect(rule.Status.MatchedNodes).To(Equal(int32(100)))
+			Expect(rule.Status.ReadyNodes).To(Equal(int32(90)))
+			Expect(rule.Status.TaintedNodes).To(Equal(int32(10)))
+			Expect(rule.Status.Conditions).To(BeEmpty())
+
+			readinessController.markNodeOutcomesRebuilt(rule.Name)
+			readinessController.setRuleSummary(rule)
+			Expect(rule.Status.MatchedNodes).To(Equal(int32(1)))
+			Expect(rule.Status.ReadyNodes).To(Equal(int32(1)))
+			Expect(rule.Status.TaintedNodes).To(BeZero())
+
+			// A new pass starts from partial outcomes again
+			readinessController.resetNodeOutcomes(rule.Name)
+			Expect(readinessController.nodeOutcomesRebuilt(rule.Name)).To(BeFalse())
+		})
+
+		It("should replace the completion of an earlier rule with the same name", func() {
+			previous := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "recreated-rule", UID: "old-uid"},
+			}
+			current := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "recreated-rule", UID: "new-uid"},
+			}
+			node := &corev1.Node{}
+
+			recordBootstrapCompletion(node, previous, bootstrapCompletion{Rule: previous.Name})
+			_, completed := getBootstrapCompletion(node, current)
+			Expect(completed).To(BeFalse())
+
+			recordBootstrapCompletion(node, current, bootstrapCompletion{Rule: current.Name})
+			Expect(getBootstrapCompletions(node)).To(HaveLen(1))
+			Expect(getBootstrapCompletions(node)).To(HaveKey(current.UID))
+		})
+
+		It("should re-arm completed bootstrap-only rules per rearmOn policy", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "rearm-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeBootstrapOnly,
+					RearmOn: &nodereadinessiov1alpha1.RearmPolicy{
+						BootID:         true,
+						KubeletVersion: true,
+						Labels:         []string{"example.com/image"},
+					},
+				},
+			}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"example.com/image": "v1"},
+				},
+				Status: corev1.NodeStatus{
+					NodeInfo: corev1.NodeSystemInfo{BootID: "boot-1", KubeletVersion: "v1.34.0"},
+				},
+			}
+			recordBootstrapCompletion(node, rule, newBootstrapCompletion(node, rule))
+
+			Expect(bootstrapRearmReason(node, rule)).To(BeEmpty())
+
+			rebooted := node.DeepCopy()
+			rebooted.Status.NodeInfo.BootID = "boot-2"
+			Expect(bootstrapRearmReason(rebooted, rule)).To(Equal("BootIDChanged"))
+
+			upgraded := node.DeepCopy()
+			upgraded.Status.NodeInfo.KubeletVersion = "v1.35.0"
+			Expect(bootstrapRearmReason(upgraded, rule)).To(Equal("KubeletVersionChanged"))
+
+			relabeled := node.DeepCopy()
+			relabeled.Labels["example.com/image"] = "v2"
+			Expect(bootstrapRearmReason(relabeled, rule)).To(Equal("LabelChanged"))
+
+			// Without a policy, or with a legacy completion mark, the rule stays completed
+			noPolicy := rule.DeepCopy()
+			noPolicy.Spec.RearmOn = nil
+			Expect(bootstrapRearmReason(rebooted, noPolicy)).To(BeEmpty())
+
+			legacy := rebooted.DeepCopy()
+			delete(legacy.Annotations, bootstrapCompletedAnnotation)
+			legacy.Annotations[legacyBootstrapAnnotationKey(rule.Name)] = "true"
+			Expect(bootstrapRearmReason(legacy, rule)).To(BeEmpty())
+		})
+		It("should ignore conditions reported before a re-arm", func() {
+			rule := &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "rearmed-rule", UID: "rearmed-uid"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeBootstrapOnly,
+				},
+			}
+			beforeReboot := metav1.NewTime(time.Now().Add(-time.Hour))
+			node := &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{
+						Type: "TestReady", Status: corev1.ConditionTrue,
+						LastHeartbeatTime: beforeReboot, LastTransitionTime: beforeReboot,
+					}},
+				},
+			}
+			recordBootstrapCompletion(node, rule, bootstrapCompletion{
+				Rule:      rule.Name,
+				RearmedAt: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			})
+			Expect(isBootstrapCompleted(node, rule)).To(BeFalse())
+
+			// The True condition is left over from the previous boot
+			eval := readinessController.evaluateConditions(rule, node)
+			Expect(eval.satisfied).To(BeFalse())
+			Expect(eval.conditionResults[0].Stale).To(BeTrue())
+			Expect(eval.requeueAfter).To(Equal(rearmRecheck))
+
+			// The agent reports again after the re-arm
+			node.Status.Conditions[0].LastHeartbeatTime = metav1.Now()
+			Expect(readinessController.evaluateConditions(rule, node).satisfied).To(BeTrue())
 		})
 	})
 
@@ -465,7 +1138,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 				ObjectMeta: metav1.ObjectMeta{Name: "db-rule"},
 				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
 					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "DBReady", RequiredStatus: corev1.ConditionTrue}},
-					Taint:           nodereadinessiov1alpha1.TaintSpec{Key: "db-unready", Effect: corev1.TaintEffectNoSchedule},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "db-unready", Effect: corev1.TaintEffectNoSchedule},
 					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
 					NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "backend"}},
 				},
@@ -500,12 +1173,10 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 				return false
 			}, time.Second*5).Should(BeTrue())
 
-			// Verify the status of the rule
-			Eventually(func() []string {
-				updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
-				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "db-rule"}, updatedRule)
-				return updatedRule.Status.AppliedNodes
-			}, time.Second*5).Should(ContainElement("node1"))
+			// Verify the node reports the evaluation of the rule
+			Eventually(func() *nodereadinessiov1alpha1.RuleEvaluation {
+				return fetchRuleEvaluation(ctx, "node1", "db-rule")
+			}, time.Second*5).ShouldNot(BeNil())
 		})
 	})
 
@@ -518,7 +1189,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 				ObjectMeta: metav1.ObjectMeta{Name: "new-node-rule"},
 				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
 					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
-					Taint:           nodereadinessiov1alpha1.TaintSpec{Key: "test-unready", Effect: corev1.TaintEffectNoSchedule},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "test-unready", Effect: corev1.TaintEffectNoSchedule},
 					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
 					NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"node-group": "new-workers"}},
 				},
@@ -551,15 +1222,10 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			// Verify that the rule's status is updated to include the new node
-			Eventually(func() []string {
-				updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
-				err := k8sClient.Get(ctx, types.NamespacedName{Name: "new-node-rule"}, updatedRule)
-				if err != nil {
-					return nil
-				}
-				return updatedRule.Status.AppliedNodes
-			}, time.Second*5, time.Millisecond*250).Should(ContainElement("new-node"))
+			// Verify that the new node reports the evaluation of the rule
+			Eventually(func() *nodereadinessiov1alpha1.RuleEvaluation {
+				return fetchRuleEvaluation(ctx, "new-node", "new-node-rule")
+			}, time.Second*5, time.Millisecond*250).ShouldNot(BeNil())
 
 			// Verify that the new node gets tainted
 			Eventually(func() bool {
@@ -578,16 +1244,830 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 		})
 	})
 
-	Context("when a rule is deleted", func() {
+	Context("when the global dry run is enabled", func() {
 		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
-		var testNode *corev1.Node
+		var node *corev1.Node
 
-		BeforeEach(func() {
-			testNode = &corev1.Node{
-				ObjectMeta: metav1.ObjectMeta{Name: "cleanup-test-node"},
-				Spec: corev1.NodeSpec{
-					Taints: []corev1.Taint{
-						{Key: "cleanup-taint", Effect: corev1.TaintEffectNoSchedule, Value: "pending"},
+		hasRuleTaint := func() bool {
+			updatedNode := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: node.Name}, updatedNode)).To(Succeed())
+			for _, taint := range updatedNode.Spec.Taints {
+				if taint.Key == rule.Spec.Taint.Key {
+					return true
+				}
+			}
+			return false
+		}
+		reconcileRule := func() {
+			_, err := ruleReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: rule.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			node = &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "frozen-node",
+					Labels: map[string]string{"node-group": "frozen"},
+				},
+			}
+			rule = &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "frozen-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "frozen-unready", Effect: corev1.TaintEffectNoSchedule},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+					NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"node-group": "frozen"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			readinessController.SetGlobalDryRun(false)
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, rule))).To(Succeed())
+			_, _ = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name}})
+			Expect(k8sClient.Delete(ctx, node)).To(Succeed())
+		})
+
+		It("should report the impact of rules without tainting nodes", func() {
+			readinessController.SetGlobalDryRun(true)
+			reconcileRule()
+			Expect(hasRuleTaint()).To(BeFalse())
+
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule)).To(Succeed())
+			Expect(updatedRule.Status.DryRunResults).NotTo(BeNil())
+			Expect(updatedRule.Status.DryRunResults.TaintsToAdd).To(Equal(1))
+			dryRun := meta.FindStatusCondition(updatedRule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDryRun)
+			Expect(dryRun).NotTo(BeNil())
+			Expect(dryRun.Status).To(Equal(metav1.ConditionTrue))
+			Expect(dryRun.Reason).To(Equal("GlobalDryRun"))
+
+			// Enforcement resumes once the dry run is lifted
+			readinessController.SetGlobalDryRun(false)
+			reconcileRule()
+			Expect(hasRuleTaint()).To(BeTrue())
+		})
+
+		It("should defer taint cleanup of deleted rules", func() {
+			reconcileRule()
+			Expect(hasRuleTaint()).To(BeTrue())
+
+			readinessController.SetGlobalDryRun(true)
+			Expect(k8sClient.Delete(ctx, rule)).To(Succeed())
+			reconcileRule()
+			Expect(hasRuleTaint()).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, &nodereadinessiov1alpha1.NodeReadinessGateRule{})).To(Succeed())
+
+			readinessController.SetGlobalDryRun(false)
+			reconcileRule()
+			Expect(hasRuleTaint()).To(BeFalse())
+		})
+
+		It("should follow the ConfigMap toggle and requeue every rule", func() {
+			DeferCleanup(func(ruleEvents chan event.TypedGenericEvent[*nodereadinessiov1alpha1.NodeReadinessGateRule]) {
+				readinessController.ruleEvents = ruleEvents
+				readinessController.setRuntimeDryRun(false)
+			}, readinessController.ruleEvents)
+			readinessController.ruleEvents = make(chan event.TypedGenericEvent[*nodereadinessiov1alpha1.NodeReadinessGateRule], 100)
+			configReconciler := &DryRunConfigReconciler{
+				Client:     k8sClient,
+				Controller: readinessController,
+				Namespace:  "default",
+				Name:       "dry-run-toggle",
+			}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "dry-run-toggle"}}
+			reconcileToggle := func() {
+				_, err := configReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dry-run-toggle"},
+				Data:       map[string]string{globalDryRunKey: "true"},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			reconcileToggle()
+			Expect(readinessController.isGlobalDryRun()).To(BeTrue())
+			Expect(readinessController.ruleEvents).NotTo(BeEmpty())
+			for len(readinessController.ruleEvents) > 0 {
+				<-readinessController.ruleEvents
+			}
+
+			// Invalid values keep the current mode
+			configMap.Data[globalDryRunKey] = "maybe"
+			Expect(k8sClient.Update(ctx, configMap)).To(Succeed())
+			reconcileToggle()
+			Expect(readinessController.isGlobalDryRun()).To(BeTrue())
+			Expect(readinessController.ruleEvents).To(BeEmpty())
+
+			// Deleting the ConfigMap lifts the dry run
+			Expect(k8sClient.Delete(ctx, configMap)).To(Succeed())
+			reconcileToggle()
+			Expect(readinessController.isGlobalDryRun()).To(BeFalse())
+			Expect(readinessController.ruleEvents).NotTo(BeEmpty())
+		})
+	})
+
+	Context("when a rule has a maxUnavailable", func() {
+		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
+		var nodeNames []string
+		var recorder *record.FakeRecorder
+
+		taintedNodes := func() []string {
+			var tainted []string
+			for _, nodeName := range nodeNames {
+				node := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+				for _, taint := range node.Spec.Taints {
+					if taint.Key == rule.Spec.Taint.Key {
+						tainted = append(tainted, nodeName)
+					}
+				}
+			}
+			return tainted
+		}
+		setNodeReady := func(nodeName string) {
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+			node.Status.Conditions = []corev1.NodeCondition{{
+				Type:               "TestReady",
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+		}
+		reconcileRule := func() reconcile.Result {
+			result, err := ruleReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: rule.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+		degradedCondition := func() *metav1.Condition {
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule)).To(Succeed())
+			return meta.FindStatusCondition(updatedRule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDegraded)
+		}
+
+		BeforeEach(func() {
+			previousRecorder := readinessController.recorder
+			DeferCleanup(func() { readinessController.recorder = previousRecorder })
+			recorder = record.NewFakeRecorder(10)
+			readinessController.recorder = recorder
+
+			nodeNames = []string{"limited-node-1", "limited-node-2", "limited-node-3"}
+			for _, nodeName := range nodeNames {
+				Expect(k8sClient.Create(ctx, &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   nodeName,
+						Labels: map[string]string{"node-group": "limited"},
+					},
+				})).To(Succeed())
+			}
+
+			maxUnavailable := intstr.FromInt32(1)
+			rule = &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "limited-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "limited-unready", Effect: corev1.TaintEffectNoSchedule},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+					NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"node-group": "limited"}},
+					MaxUnavailable:  &maxUnavailable,
+				},
+			}
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, rule))).To(Succeed())
+			_, _ = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name}})
+			for _, nodeName := range nodeNames {
+				Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+			}
+		})
+
+		It("should hold back taints beyond the limit and keep removing them", func() {
+			result := reconcileRule()
+			Expect(result.RequeueAfter).To(Equal(maxUnavailableRecheck))
+			tainted := taintedNodes()
+			Expect(tainted).To(HaveLen(1))
+
+			degraded := degradedCondition()
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("MaxUnavailableExceeded"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("MaxUnavailableExceeded")))
+
+			// Removals continue, which frees room for a held back node
+			setNodeReady(tainted[0])
+			reconcileRule()
+			// Nodes evaluated alongside the removal get the freed room on their recheck
+			reconcileRule()
+			Expect(taintedNodes()).To(HaveLen(1))
+			Expect(taintedNodes()).NotTo(ContainElement(tainted[0]))
+
+			// Additions resume once every node fits within the limit again
+			for _, nodeName := range nodeNames {
+				if nodeName != tainted[0] {
+					setNodeReady(nodeName)
+				}
+			}
+			Expect(reconcileRule().RequeueAfter).To(BeZero())
+			Expect(taintedNodes()).To(BeEmpty())
+			Expect(degradedCondition().Status).To(Equal(metav1.ConditionFalse))
+			Expect(recorder.Events).To(Receive(ContainSubstring("TaintAdditionsResumed")))
+		})
+
+		It("should hold back taints until the gated nodes are counted", func() {
+			reconcileRule()
+			tainted := taintedNodes()
+			Expect(tainted).To(HaveLen(1))
+
+			// After a restart only the reconciled node would be counted
+			readinessController.resetNodeOutcomes(rule.Name)
+			for _, nodeName := range nodeNames {
+				if nodeName == tainted[0] {
+					continue
+				}
+				_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: nodeName}})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(taintedNodes()).To(ConsistOf(tainted))
+
+			reconcileRule()
+			Expect(taintedNodes()).To(ConsistOf(tainted))
+		})
+
+		It("should hold back taints of rules with a condition action", func() {
+			rule.Spec.Actions = &nodereadinessiov1alpha1.GateActions{Condition: &nodereadinessiov1alpha1.ConditionAction{}}
+			Expect(k8sClient.Update(ctx, rule)).To(Succeed())
+
+			// Nodes that do not report the gate condition yet are not gated
+			Expect(reconcileRule().RequeueAfter).To(Equal(maxUnavailableRecheck))
+			Expect(taintedNodes()).To(HaveLen(1))
+			Expect(degradedCondition().Reason).To(Equal("MaxUnavailableExceeded"))
+		})
+
+		It("should apply the global limit across rules", func() {
+			globalLimit := intstr.FromString("50%")
+			readinessController.SetGlobalMaxUnavailable(&globalLimit)
+			DeferCleanup(readinessController.SetGlobalMaxUnavailable, (*intstr.IntOrString)(nil))
+
+			rule.Spec.MaxUnavailable = nil
+			Expect(k8sClient.Update(ctx, rule)).To(Succeed())
+
+			// 50% of three nodes rounds up to two
+			reconcileRule()
+			Expect(taintedNodes()).To(HaveLen(2))
+			Expect(degradedCondition().Reason).To(Equal("GlobalMaxUnavailableExceeded"))
+		})
+	})
+
+	Context("when a rule has a rollout strategy", func() {
+		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
+		nodeZones := map[string]string{
+			"rollout-node-1": "zone-b",
+			"rollout-node-2": "zone-a",
+			"rollout-node-3": "zone-a",
+			"rollout-node-4": "zone-b",
+		}
+
+		taintedNodes := func() []string {
+			var tainted []string
+			for _, nodeName := range slices.Sorted(maps.Keys(nodeZones)) {
+				node := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+				for _, taint := range node.Spec.Taints {
+					if taint.Key == rule.Spec.Taint.Key {
+						tainted = append(tainted, nodeName)
+					}
+				}
+			}
+			return tainted
+		}
+		reconcileRule := func() reconcile.Result {
+			result, err := ruleReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: rule.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+		getRule := func() *nodereadinessiov1alpha1.NodeReadinessGateRule {
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule)).To(Succeed())
+			return updatedRule
+		}
+		endPause := func() {
+			updatedRule := getRule()
+			updatedRule.Status.Rollout.LastBatchTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			Expect(k8sClient.Status().Update(ctx, updatedRule)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			for nodeName, zone := range nodeZones {
+				Expect(k8sClient.Create(ctx, &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   nodeName,
+						Labels: map[string]string{"node-group": "rollout", "topology.kubernetes.io/zone": zone},
+					},
+				})).To(Succeed())
+			}
+
+			rule = &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollout-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "rollout-unready", Effect: corev1.TaintEffectNoSchedule},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+					NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"node-group": "rollout"}},
+					Rollout: &nodereadinessiov1alpha1.RolloutStrategy{
+						BatchSize: intstr.FromInt32(1),
+						Pause:     metav1.Duration{Duration: time.Hour},
+						OrderBy:   "topology.kubernetes.io/zone",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, rule))).To(Succeed())
+			_, _ = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name}})
+			for nodeName := range nodeZones {
+				Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+			}
+		})
+
+		It("should taint nodes batch by batch in label order", func() {
+			result := reconcileRule()
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			Expect(taintedNodes()).To(Equal([]string{"rollout-node-2"}))
+
+			rollout := getRule().Status.Rollout
+			Expect(rollout).NotTo(BeNil())
+			Expect(rollout.Batches).To(BeEquivalentTo(1))
+			Expect(rollout.ReleasedNodes).To(BeEquivalentTo(1))
+			Expect(rollout.LastNodeName).To(Equal("rollout-node-2"))
+			Expect(rollout.LastOrderValue).To(Equal("zone-a"))
+			progressing := meta.FindStatusCondition(getRule().Status.Conditions, nodereadinessiov1alpha1.RuleConditionProgressing)
+			Expect(progressing.Reason).To(Equal("RolloutInProgress"))
+
+			// Node events do not get ahead of the rollout
+			_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "rollout-node-3"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taintedNodes()).To(Equal([]string{"rollout-node-2"}))
+
+			// Progress is restored from status, e.g. after a restart
+			readinessController.forgetRollout(rule.Name)
+			reconcileRule()
+			Expect(taintedNodes()).To(Equal([]string{"rollout-node-2"}))
+
+			endPause()
+			reconcileRule()
+			Expect(taintedNodes()).To(Equal([]string{"rollout-node-2", "rollout-node-3"}))
+
+			endPause()
+			reconcileRule()
+			endPause()
+			Expect(reconcileRule().RequeueAfter).To(BeZero())
+			Expect(taintedNodes()).To(HaveLen(4))
+			rollout = getRule().Status.Rollout
+			Expect(rollout.Complete).To(BeTrue())
+			Expect(rollout.Batches).To(BeEquivalentTo(4))
+			progressing = meta.FindStatusCondition(getRule().Status.Conditions, nodereadinessiov1alpha1.RuleConditionProgressing)
+			Expect(progressing.Status).To(Equal(metav1.ConditionFalse))
+		})
+
+		It("should hold back nodes of rules with a condition action", func() {
+			rule.Spec.Actions = &nodereadinessiov1alpha1.GateActions{Condition: &nodereadinessiov1alpha1.ConditionAction{}}
+			Expect(k8sClient.Update(ctx, rule)).To(Succeed())
+
+			// Nodes that do not report the gate condition yet wait for their batch
+			reconcileRule()
+			Expect(taintedNodes()).To(Equal([]string{"rollout-node-2"}))
+
+			_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "rollout-node-3"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taintedNodes()).To(Equal([]string{"rollout-node-2"}))
+		})
+
+		It("should only count batches that release nodes", func() {
+			reconcileRule()
+			endPause()
+			updatedRule := getRule()
+			batchTime := updatedRule.Status.Rollout.LastBatchTime
+
+			// The nodes after the last released one are gone
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rollout-node-2"}, node)).To(Succeed())
+			Expect(readinessController.advanceRollout(ctx, updatedRule, []corev1.Node{*node})).To(BeZero())
+
+			rollout := updatedRule.Status.Rollout
+			Expect(rollout.Complete).To(BeTrue())
+			Expect(rollout.Batches).To(BeEquivalentTo(1))
+			Expect(rollout.LastBatchTime).To(Equal(batchTime))
+		})
+
+		It("should restart only when what the rule enforces changes", func() {
+			reconcileRule()
+			updatedRule := getRule()
+			updatedRule.Status.Rollout.LastBatchTime = &metav1.Time{Time: time.Now().Add(-30 * time.Minute)}
+			Expect(k8sClient.Status().Update(ctx, updatedRule)).To(Succeed())
+			batchTime := getRule().Status.Rollout.LastBatchTime
+
+			// A slower pace continues the current rollout
+			updatedRule = getRule()
+			updatedRule.Spec.Rollout.Pause = metav1.Duration{Duration: 2 * time.Hour}
+			Expect(k8sClient.Update(ctx, updatedRule)).To(Succeed())
+			reconcileRule()
+			rollout := getRule().Status.Rollout
+			Expect(rollout.ObservedGeneration).To(Equal(getRule().Generation))
+			Expect(rollout.Batches).To(BeEquivalentTo(1))
+			Expect(rollout.LastBatchTime).To(Equal(batchTime))
+			Expect(taintedNodes()).To(Equal([]string{"rollout-node-2"}))
+
+			// A new condition starts over
+			updatedRule = getRule()
+			updatedRule.Spec.Conditions = append(updatedRule.Spec.Conditions, nodereadinessiov1alpha1.ConditionRequirement{
+				Type: "OtherReady", RequiredStatus: corev1.ConditionTrue,
+			})
+			Expect(k8sClient.Update(ctx, updatedRule)).To(Succeed())
+			reconcileRule()
+			rollout = getRule().Status.Rollout
+			Expect(rollout.Batches).To(BeEquivalentTo(1))
+			Expect(rollout.LastBatchTime.Time).To(BeTemporally(">", batchTime.Time))
+		})
+	})
+
+	Context("when a rule has a canary", func() {
+		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
+		var recorder *record.FakeRecorder
+		nodeNames := []string{"canary-node-1", "canary-node-2", "canary-node-3"}
+
+		taintedNodes := func() []string {
+			var tainted []string
+			for _, nodeName := range nodeNames {
+				node := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+				for _, taint := range node.Spec.Taints {
+					if taint.Key == rule.Spec.Taint.Key {
+						tainted = append(tainted, nodeName)
+					}
+				}
+			}
+			return tainted
+		}
+		reconcileRule := func() reconcile.Result {
+			result, err := ruleReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: rule.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+		getRule := func() *nodereadinessiov1alpha1.NodeReadinessGateRule {
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule)).To(Succeed())
+			return updatedRule
+		}
+
+		BeforeEach(func() {
+			previousRecorder := readinessController.recorder
+			DeferCleanup(func() { readinessController.recorder = previousRecorder })
+			recorder = record.NewFakeRecorder(10)
+			readinessController.recorder = recorder
+
+			for _, nodeName := range nodeNames {
+				nodeLabels := map[string]string{"node-group": "canary"}
+				if nodeName == "canary-node-1" {
+					nodeLabels["canary"] = "true"
+				}
+				Expect(k8sClient.Create(ctx, &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: nodeName, Labels: nodeLabels},
+				})).To(Succeed())
+			}
+
+			rule = &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "canary-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "canary-unready", Effect: corev1.TaintEffectNoSchedule},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+					NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"node-group": "canary"}},
+					Canary: &nodereadinessiov1alpha1.CanaryStrategy{
+						Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+						PromoteAfter: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, rule))).To(Succeed())
+			_, _ = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name}})
+			for _, nodeName := range nodeNames {
+				Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+			}
+		})
+
+		It("should enforce on the canary nodes and promote once they stay compliant", func() {
+			Expect(reconcileRule().RequeueAfter).To(Equal(canaryRecheck))
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-1"}))
+
+			updatedRule := getRule()
+			Expect(updatedRule.Status.Canary).NotTo(BeNil())
+			Expect(updatedRule.Status.Canary.CanaryNodes).To(BeEquivalentTo(1))
+			Expect(updatedRule.Status.DryRunResults).NotTo(BeNil())
+			Expect(updatedRule.Status.DryRunResults.TaintsToAdd).To(BeEquivalentTo(2))
+			dryRun := meta.FindStatusCondition(updatedRule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDryRun)
+			Expect(dryRun.Reason).To(Equal("CanaryEnforcement"))
+
+			// Node events leave the other nodes alone
+			_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "canary-node-2"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-1"}))
+
+			// The canary node turns compliant, which starts the promotion clock
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "canary-node-1"}, node)).To(Succeed())
+			node.Status.Conditions = []corev1.NodeCondition{{
+				Type:               "TestReady",
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+			// The dry run plan of the other nodes is refreshed before promotion is due
+			Expect(reconcileRule().RequeueAfter).To(Equal(dryRunRefreshInterval))
+			Expect(taintedNodes()).To(BeEmpty())
+			Expect(getRule().Status.Canary.CompliantSince).NotTo(BeNil())
+
+			updatedRule = getRule()
+			updatedRule.Status.Canary.CompliantSince = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			Expect(k8sClient.Status().Update(ctx, updatedRule)).To(Succeed())
+			reconcileRule()
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-2", "canary-node-3"}))
+			Expect(recorder.Events).To(Receive(ContainSubstring("CanaryPromoted")))
+
+			updatedRule = getRule()
+			Expect(updatedRule.Status.Canary.Promoted).To(BeTrue())
+			Expect(updatedRule.Status.Canary.PromotionTime).NotTo(BeNil())
+			dryRun = meta.FindStatusCondition(updatedRule.Status.Conditions, nodereadinessiov1alpha1.RuleConditionDryRun)
+			Expect(dryRun.Reason).To(Equal("Enforcing"))
+
+			// Promotion is restored from status, e.g. after a restart
+			readinessController.forgetCanary(rule.Name)
+			reconcileRule()
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-2", "canary-node-3"}))
+
+			// A spec change that keeps what is enforced keeps the promotion
+			updatedRule = getRule()
+			updatedRule.Spec.Canary.PromoteAfter = &metav1.Duration{Duration: 2 * time.Hour}
+			Expect(k8sClient.Update(ctx, updatedRule)).To(Succeed())
+			reconcileRule()
+			Expect(getRule().Status.Canary.Promoted).To(BeTrue())
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-2", "canary-node-3"}))
+		})
+
+		It("should hold back the other nodes of rules with a condition action", func() {
+			rule.Spec.Actions = &nodereadinessiov1alpha1.GateActions{Condition: &nodereadinessiov1alpha1.ConditionAction{}}
+			Expect(k8sClient.Update(ctx, rule)).To(Succeed())
+
+			// Nodes that do not report the gate condition yet wait for the promotion
+			reconcileRule()
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-1"}))
+
+			_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "canary-node-2"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-1"}))
+		})
+
+		It("should remove owned taints from the other nodes while the canary is active", func() {
+			setNodeReady := func(nodeName string) {
+				node := &corev1.Node{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+				node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: "canary-unready", Effect: corev1.TaintEffectNoSchedule})
+				recordOwnedTaints(node, getRule(), getRule().Spec.GetTaints())
+				Expect(k8sClient.Update(ctx, node)).To(Succeed())
+				node.Status.Conditions = []corev1.NodeCondition{{
+					Type:               "TestReady",
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Now(),
+				}}
+				Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+			}
+
+			// Added before the canary started
+			setNodeReady("canary-node-2")
+			reconcileRule()
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-1"}))
+
+			setNodeReady("canary-node-3")
+			_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "canary-node-3"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taintedNodes()).To(Equal([]string{"canary-node-1"}))
+		})
+	})
+
+	Context("when a rule is in dry run", func() {
+		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
+		nodeNames := []string{"plan-node-1", "plan-node-2"}
+
+		reconcileRule := func() reconcile.Result {
+			result, err := ruleReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: rule.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+		getRule := func() *nodereadinessiov1alpha1.NodeReadinessGateRule {
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule)).To(Succeed())
+			return updatedRule
+		}
+		setNodeReady := func(nodeName string) {
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+			node.Status.Conditions = []corev1.NodeCondition{{
+				Type:               "TestReady",
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+		}
+		publishedPlan := func() []nodereadinessiov1alpha1.NodeDryRunPlan {
+			configMap, err := fakeClientset.CoreV1().ConfigMaps("default").Get(ctx, "nrg-dry-run-plan-rule", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			var plan []nodereadinessiov1alpha1.NodeDryRunPlan
+			Expect(json.Unmarshal([]byte(configMap.Data["plan.json"]), &plan)).To(Succeed())
+			return plan
+		}
+
+		BeforeEach(func() {
+			DeferCleanup(readinessController.SetDryRunPlanNamespace, readinessController.dryRunPlanNamespace)
+			readinessController.SetDryRunPlanNamespace("default")
+
+			for _, nodeName := range nodeNames {
+				Expect(k8sClient.Create(ctx, &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   nodeName,
+						Labels: map[string]string{"node-group": "plan"},
+					},
+				})).To(Succeed())
+			}
+			setNodeReady("plan-node-2")
+
+			rule = &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "plan-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "plan-unready", Effect: corev1.TaintEffectNoSchedule},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+					NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"node-group": "plan"}},
+					DryRun:          true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, rule))).To(Succeed())
+			_, _ = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name}})
+			for _, nodeName := range nodeNames {
+				Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).To(Succeed())
+			}
+		})
+
+		It("should keep plan ConfigMap names valid for long rule names", func() {
+			Expect(dryRunPlanConfigMapName("plan-rule")).To(Equal("nrg-dry-run-plan-rule"))
+
+			name := dryRunPlanConfigMapName(strings.Repeat("long-rule.", 25))
+			Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+			Expect(name).NotTo(Equal(dryRunPlanConfigMapName(strings.Repeat("long-rule.", 24) + "long-rule-")))
+		})
+
+		It("should report a per-node plan and keep it up to date", func() {
+			Expect(reconcileRule().RequeueAfter).To(Equal(dryRunRefreshInterval))
+
+			results := getRule().Status.DryRunResults
+			Expect(results).NotTo(BeNil())
+			Expect(results.TaintsToAdd).To(Equal(1))
+			Expect(results.PlanConfigMap).To(Equal("default/nrg-dry-run-plan-rule"))
+			expectedPlan := []nodereadinessiov1alpha1.NodeDryRunPlan{{
+				NodeName:          "plan-node-1",
+				Action:            nodereadinessiov1alpha1.DryRunActionTaint,
+				FailingConditions: []string{"TestReady: missing"},
+			}}
+			Expect(results.Nodes).To(Equal(expectedPlan))
+			Expect(publishedPlan()).To(Equal(expectedPlan))
+
+			// Node events update the plan
+			setNodeReady("plan-node-1")
+			_, err := nodeReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "plan-node-1"}})
+			Expect(err).NotTo(HaveOccurred())
+			readinessController.flushRuleStatuses(ctx)
+
+			results = getRule().Status.DryRunResults
+			Expect(results.TaintsToAdd).To(BeZero())
+			Expect(results.Nodes).To(BeEmpty())
+			Expect(publishedPlan()).To(BeEmpty())
+
+			// The published plan is deleted once the rule is enforced
+			updatedRule := getRule()
+			updatedRule.Spec.DryRun = false
+			Expect(k8sClient.Update(ctx, updatedRule)).To(Succeed())
+			reconcileRule()
+			Expect(getRule().Status.DryRunResults).To(BeNil())
+			_, err = fakeClientset.CoreV1().ConfigMaps("default").Get(ctx, "nrg-dry-run-plan-rule", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("when node events change rule status", func() {
+		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
+
+		BeforeEach(func() {
+			rule = &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "batched-status-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "batched-unready", Effect: corev1.TaintEffectNoSchedule},
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+				},
+			}
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, rule))).To(Succeed())
+		})
+
+		It("should coalesce queued changes and write them in one patch", func() {
+			readinessController.recordNodeFailure(rule, "node-a", "EvaluationError", "first")
+			readinessController.queueRuleStatus(rule)
+			firstQueued := readinessController.batcher().pending[rule.Name].since
+
+			readinessController.recordNodeOutcome(rule.Name, "node-b", nodeOutcome{gated: true})
+			readinessController.markNodeOutcomesRebuilt(rule.Name)
+			readinessController.recordNodeFailure(rule, "node-b", "EvaluationError", "second")
+			readinessController.queueRuleStatus(rule)
+
+			Expect(readinessController.batcher().pending).To(HaveLen(1))
+			Expect(readinessController.batcher().pending[rule.Name].since).To(Equal(firstQueued))
+
+			readinessController.flushRuleStatuses(ctx)
+			Expect(readinessController.batcher().pending).To(BeEmpty())
+
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rule.Name}, updatedRule)).To(Succeed())
+			Expect(updatedRule.Status.FailingNodes).To(HaveLen(2))
+			Expect(updatedRule.Status.MatchedNodes).To(Equal(int32(2)))
+			Expect(updatedRule.Status.TaintedNodes).To(Equal(int32(1)))
+			Expect(updatedRule.Status.FailedNodes).To(Equal(int32(2)))
+		})
+
+		It("should signal an early flush once enough changes are queued", func() {
+			batcher := readinessController.batcher()
+			DeferCleanup(func() {
+				readinessController.flushRuleStatuses(ctx)
+				select {
+				case <-batcher.flushNow:
+				default:
+				}
+			})
+			DeferCleanup(readinessController.SetStatusBatching, batcher.interval, batcher.maxPending)
+			readinessController.SetStatusBatching(time.Minute, 2)
+
+			readinessController.queueRuleStatus(rule)
+			Expect(readinessController.batcher().flushNow).To(BeEmpty())
+
+			readinessController.queueRuleStatus(rule)
+			Expect(readinessController.batcher().flushNow).To(HaveLen(1))
+		})
+
+		It("should drop queued changes of a deleted rule", func() {
+			readinessController.queueRuleStatus(rule)
+			Expect(k8sClient.Delete(ctx, rule)).To(Succeed())
+
+			readinessController.flushRuleStatuses(ctx)
+			Expect(readinessController.batcher().pending).To(BeEmpty())
+		})
+	})
+
+	Context("when a rule is deleted", func() {
+		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
+		var testNode *corev1.Node
+
+		BeforeEach(func() {
+			testNode = &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "cleanup-test-node"},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{Key: "cleanup-taint", Effect: corev1.TaintEffectNoSchedule, Value: "pending"},
 					},
 				},
 				Status: corev1.NodeStatus{
@@ -598,7 +2078,8 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 				ObjectMeta: metav1.ObjectMeta{Name: "cleanup-rule"},
 				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
 					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "TestReady", RequiredStatus: corev1.ConditionTrue}},
-					Taint:           nodereadinessiov1alpha1.TaintSpec{Key: "cleanup-taint", Effect: corev1.TaintEffectNoSchedule},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "cleanup-taint", Effect: corev1.TaintEffectNoSchedule},
+					AdoptExisting:   true,
 					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
 				},
 			}
@@ -675,7 +2156,7 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 				ObjectMeta: metav1.ObjectMeta{Name: "delete-node-rule"},
 				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
 					Conditions:      []nodereadinessiov1alpha1.ConditionRequirement{{Type: "Ready", RequiredStatus: corev1.ConditionTrue}},
-					Taint:           nodereadinessiov1alpha1.TaintSpec{Key: "unready", Effect: corev1.TaintEffectNoSchedule},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "unready", Effect: corev1.TaintEffectNoSchedule},
 					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
 				},
 			}
@@ -698,42 +2179,167 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 			_, err := ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "delete-node-rule"}})
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(func() int {
-				updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
-				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "delete-node-rule"}, updatedRule)
-				return len(updatedRule.Status.NodeEvaluations)
-			}, time.Second*5).Should(Equal(2))
+			Expect(fetchRuleEvaluation(ctx, "node1", "delete-node-rule")).NotTo(BeNil())
+			Expect(fetchRuleEvaluation(ctx, "node2", "delete-node-rule")).NotTo(BeNil())
+
+			// Record a failure for each node
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "delete-node-rule"}, updatedRule)).To(Succeed())
+			readinessController.recordNodeFailure(updatedRule, "node1", "EvaluationError", "test")
+			readinessController.recordNodeFailure(updatedRule, "node2", "EvaluationError", "test")
+			Expect(k8sClient.Status().Update(ctx, updatedRule)).To(Succeed())
 
 			// Delete node1
 			Expect(k8sClient.Delete(ctx, node1)).To(Succeed())
 
-			// Reconcile again to trigger cleanup
-			_, err = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "delete-node-rule"}})
-			Expect(err).NotTo(HaveOccurred())
+			// Clean up failing node samples of deleted nodes
+			Expect(readinessController.cleanupDeletedNodes(ctx, updatedRule)).To(Succeed())
 
-			// Verify node1 is removed from status
-			Eventually(func() bool {
+			// Verify only node2 is left in status
+			Eventually(func() []string {
 				updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
 				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "delete-node-rule"}, updatedRule)
-				for _, eval := range updatedRule.Status.NodeEvaluations {
-					if eval.NodeName == "node1" {
-						return false
+				var failingNodes []string
+				for _, failure := range updatedRule.Status.FailingNodes {
+					failingNodes = append(failingNodes, failure.NodeName)
+				}
+				return failingNodes
+			}, time.Second*5).Should(Equal([]string{"node2"}))
+		})
+	})
+
+	Context("when a rule's taint changes", func() {
+		var rule *nodereadinessiov1alpha1.NodeReadinessGateRule
+		var node *corev1.Node
+
+		BeforeEach(func() {
+			node = &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "drift-node"},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{Key: "drift-taint", Effect: corev1.TaintEffectNoSchedule, Value: "pending"},
+					},
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: "TestReady", Status: corev1.ConditionFalse}},
+				},
+			}
+
+			rule = &nodereadinessiov1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "drift-rule"},
+				Spec: nodereadinessiov1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
+						{Type: "TestReady", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "drift-taint", Effect: corev1.TaintEffectNoSchedule, Value: "pending"},
+					AdoptExisting:   true,
+					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+			Expect(k8sClient.Create(ctx, rule)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, node)
+
+			// Remove finalizers so the rule can be recreated by the next spec
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "drift-rule"}, updatedRule); err == nil {
+				updatedRule.Finalizers = nil
+				_ = k8sClient.Update(ctx, updatedRule)
+				_ = k8sClient.Delete(ctx, updatedRule)
+			}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: "drift-rule"}, &nodereadinessiov1alpha1.NodeReadinessGateRule{})
+			}, time.Second*10).ShouldNot(Succeed())
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: "drift-node"}, &corev1.Node{})
+			}, time.Second*10).ShouldNot(Succeed())
+		})
+
+		It("should swap the old taint for the new one when the key changes", func() {
+			_, err := ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "drift-rule"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "drift-rule"}, updatedRule)).To(Succeed())
+			updatedRule.Spec.Taint.Key = "renamed-taint"
+			Expect(k8sClient.Update(ctx, updatedRule)).To(Succeed())
+
+			_, err = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "drift-rule"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() []string {
+				updatedNode := &corev1.Node{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "drift-node"}, updatedNode)
+				var keys []string
+				for _, taint := range updatedNode.Spec.Taints {
+					if taint.Key == "drift-taint" || taint.Key == "renamed-taint" {
+						keys = append(keys, taint.Key)
 					}
 				}
-				return true
-			}, time.Second*5).Should(BeTrue())
+				return keys
+			}, time.Second*5).Should(ConsistOf("renamed-taint"))
+		})
 
-			// Verify node2 is still in status
-			Eventually(func() bool {
-				updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
-				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "delete-node-rule"}, updatedRule)
-				for _, eval := range updatedRule.Status.NodeEvaluations {
-					if eval.NodeName == "node2" {
-						return true
+		It("should migrate taints whose key changed while the controller was down", func() {
+			_, err := ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "drift-rule"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			// A restarted controller only knows the applied taints from the rule status
+			readinessController.removeRuleFromCache(ctx, "drift-rule")
+
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "drift-rule"}, updatedRule)).To(Succeed())
+			Expect(updatedRule.Status.AppliedTaints).To(ConsistOf(HaveField("Key", "drift-taint")))
+			updatedRule.Spec.Taint.Key = "renamed-taint"
+			Expect(k8sClient.Update(ctx, updatedRule)).To(Succeed())
+
+			_, err = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "drift-rule"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() []string {
+				updatedNode := &corev1.Node{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "drift-node"}, updatedNode)
+				var keys []string
+				for _, taint := range updatedNode.Spec.Taints {
+					if taint.Key == "drift-taint" || taint.Key == "renamed-taint" {
+						keys = append(keys, taint.Key)
 					}
 				}
-				return false
-			}, time.Second*5).Should(BeTrue())
+				return keys
+			}, time.Second*5).Should(ConsistOf("renamed-taint"))
+		})
+
+		It("should migrate nodes to the new taint value and effect", func() {
+			_, err := ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "drift-rule"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedRule := &nodereadinessiov1alpha1.NodeReadinessGateRule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "drift-rule"}, updatedRule)).To(Succeed())
+			updatedRule.Spec.Taint.Value = "cni-pending"
+			updatedRule.Spec.Taint.Effect = corev1.TaintEffectNoExecute
+			Expect(k8sClient.Update(ctx, updatedRule)).To(Succeed())
+
+			_, err = ruleReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "drift-rule"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			// The old taint is replaced, not left behind next to the new one
+			Eventually(func() []corev1.Taint {
+				updatedNode := &corev1.Node{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "drift-node"}, updatedNode)
+				var taints []corev1.Taint
+				for _, taint := range updatedNode.Spec.Taints {
+					if taint.Key == "drift-taint" {
+						taints = append(taints, corev1.Taint{Key: taint.Key, Effect: taint.Effect, Value: taint.Value})
+					}
+				}
+				return taints
+			}, time.Second*5).Should(ConsistOf(
+				corev1.Taint{Key: "drift-taint", Effect: corev1.TaintEffectNoExecute, Value: "cni-pending"},
+			))
 		})
 	})
 
@@ -773,7 +2379,8 @@ var _ = Describe("NodeReadinessGateRule Controller", func() {
 					Conditions: []nodereadinessiov1alpha1.ConditionRequirement{
 						{Type: "TestReady", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint:           nodereadinessiov1alpha1.TaintSpec{Key: "selector-change-taint", Effect: corev1.TaintEffectNoSchedule},
+					Taint:           &nodereadinessiov1alpha1.TaintSpec{Key: "selector-change-taint", Effect: corev1.TaintEffectNoSchedule},
+					AdoptExisting:   true,
 					EnforcementMode: nodereadinessiov1alpha1.EnforcementModeContinuous,
 					NodeSelector: &metav1.LabelSelector{
 						MatchLabels: map[string]string{"env": "prod"},
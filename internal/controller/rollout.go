@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// minRolloutPause keeps a rollout moving if its pause is not set
+const minRolloutPause = time.Second
+
+// rolloutKey orders the nodes of a rollout: by the value of the rule's
+// OrderBy label, then by name
+type rolloutKey struct {
+	orderValue string
+	nodeName   string
+}
+
+// less checks if the key comes before another one
+func (k rolloutKey) less(other rolloutKey) bool {
+	if k.orderValue != other.orderValue {
+		return k.orderValue < other.orderValue
+	}
+	return k.nodeName < other.nodeName
+}
+
+// nodeRolloutKey returns the rollout key of a node
+func nodeRolloutKey(strategy *readinessv1alpha1.RolloutStrategy, node *corev1.Node) rolloutKey {
+	key := rolloutKey{nodeName: node.Name}
+	if strategy.OrderBy != "" {
+		key.orderValue = node.Labels[strategy.OrderBy]
+	}
+	return key
+}
+
+// rolloutState is the in-memory copy of the rollout progress of a rule,
+// consulted when node events are processed
+type rolloutState struct {
+	generation int64
+	released   bool
+	last       rolloutKey
+	complete   bool
+}
+
+// rolloutStateFrom reads the rollout progress recorded in a rule's status
+func rolloutStateFrom(status *readinessv1alpha1.RolloutStatus) rolloutState {
+	return rolloutState{
+		generation: status.ObservedGeneration,
+		released:   status.Batches > 0,
+		last:       rolloutKey{orderValue: status.LastOrderValue, nodeName: status.LastNodeName},
+		complete:   status.Complete,
+	}
+}
+
+// reached checks if enforcement has reached the node with the given key
+func (s rolloutState) reached(key rolloutKey) bool {
+	return s.complete || (s.released && !s.last.less(key))
+}
+
+// advanceRollout starts the rollout of a new rule generation and releases
+// its next batch of nodes once the pause after the previous one is over. The
+// progress is recorded in the rule status, so it survives restarts. It
+// returns when the next batch is due, or zero.
+func (r *ReadinessGateController) advanceRollout(
+	ctx context.Context,
+	rule *readinessv1alpha1.NodeReadinessGateRule,
+	nodes []corev1.Node,
+) time.Duration {
+	log := ctrl.LoggerFrom(ctx)
+
+	strategy := rule.Spec.Rollout
+	if strategy == nil {
+		rule.Status.Rollout = nil
+		r.forgetRollout(rule.Name)
+		return 0
+	}
+
+	// Spec changes that do not change what is enforced, e.g. to the rollout
+	// pace, continue the current rollout
+	hash := evaluationHash(rule)
+	status := rule.Status.Rollout
+	if status == nil || !sameEvaluation(status.ObservedGeneration, status.EvaluationHash, rule, hash) {
+		log.Info("Starting rollout", "rule", rule.Name, "generation", rule.Generation,
+			"batchSize", strategy.BatchSize.String(), "pause", strategy.Pause.Duration, "orderBy", strategy.OrderBy)
+		status = &readinessv1alpha1.RolloutStatus{}
+		rule.Status.Rollout = status
+	}
+	status.ObservedGeneration, status.EvaluationHash = rule.Generation, hash
+	defer func() {
+		r.setRollout(rule.Name, rolloutStateFrom(status))
+	}()
+
+	pause := max(strategy.Pause.Duration, minRolloutPause)
+	if status.Complete {
+		return 0
+	}
+	if status.LastBatchTime != nil {
+		if wait := time.Until(status.LastBatchTime.Add(pause)); wait > 0 {
+			return wait
+		}
+	}
+
+	sorted := slices.Clone(nodes)
+	slices.SortFunc(sorted, func(a, b corev1.Node) int {
+		keyA, keyB := nodeRolloutKey(strategy, &a), nodeRolloutKey(strategy, &b)
+		switch {
+		case keyA.less(keyB):
+			return -1
+		case keyB.less(keyA):
+			return 1
+		}
+		return 0
+	})
+
+	batchSize, err := intstr.GetScaledValueFromIntOrPercent(&strategy.BatchSize, len(sorted), true)
+	if err != nil || batchSize < 1 {
+		batchSize = 1
+	}
+
+	// Release nodes in order until the batch holds batchSize nodes that
+	// would be tainted; nodes that satisfy the rule are passed over
+	previous := rolloutStateFrom(status)
+	state := previous
+	var released, toTaint int32
+	for i := range sorted {
+		node := &sorted[i]
+		key := nodeRolloutKey(strategy, node)
+		if state.reached(key) {
+			released++
+			continue
+		}
+		if r.wouldTaint(rule, node) {
+			if int(toTaint) == batchSize {
+				break
+			}
+			toTaint++
+		}
+		state.released, state.last = true, key
+		released++
+	}
+
+	// Only a batch that releases nodes counts and starts a pause; enforcement
+	// may also reach every node without one, e.g. once later nodes are gone
+	moved := state != previous
+	status.ReleasedNodes = released
+	status.Complete = int(released) == len(sorted)
+	if moved {
+		status.LastNodeName, status.LastOrderValue = state.last.nodeName, state.last.orderValue
+		status.Batches++
+		status.LastBatchTime = &metav1.Time{Time: time.Now()}
+		log.Info("Released rollout batch", "rule", rule.Name, "batch", status.Batches, "nodesToTaint", toTaint,
+			"releasedNodes", released, "matchedNodes", len(sorted), "complete", status.Complete)
+	}
+
+	if status.Complete {
+		r.recordEvent(rule, corev1.EventTypeNormal, "RolloutComplete",
+			fmt.Sprintf("Rule is enforced on all %d matched nodes after %d batches", released, status.Batches))
+		return 0
+	}
+	if !moved {
+		return 0
+	}
+	r.recordEvent(rule, corev1.EventTypeNormal, "RolloutBatchReleased",
+		fmt.Sprintf("Batch %d releases %d nodes to be tainted; enforced on %d of %d matched nodes",
+			status.Batches, toTaint, released, len(sorted)))
+	return pause
+}
+
+// evaluationHash identifies what a rule checks on nodes and how it gates
+// them. Rollouts and canaries restart only when it changes.
+func evaluationHash(rule *readinessv1alpha1.NodeReadinessGateRule) string {
+	hash := fnv.New64a()
+	_ = json.NewEncoder(hash).Encode([]any{
+		rule.Spec.Conditions,
+		rule.Spec.ConditionGroups,
+		rule.Spec.Expressions,
+		rule.Spec.GetTaints(),
+		rule.Spec.Actions,
+	})
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// sameEvaluation checks if progress recorded for a rule still applies to it.
+// Progress recorded before evaluation hashes were kept is keyed by generation.
+func sameEvaluation(generation int64, hash string, rule *readinessv1alpha1.NodeReadinessGateRule, currentHash string) bool {
+	if hash == "" {
+		return generation == rule.Generation
+	}
+	return hash == currentHash
+}
+
+// wouldTaint checks if enforcing a rule on a node would add its taints
+func (r *ReadinessGateController) wouldTaint(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) bool {
+	if r.evaluateConditions(rule, node).satisfied {
+		return false
+	}
+	_, hasAllTaints := r.gateApplied(node, rule)
+	return !hasAllTaints
+}
+
+// rolloutReached checks if the rollout of a rule has reached a node. Until
+// the rule is reconciled, its rollout progress is not known and no node is
+// reached.
+func (r *ReadinessGateController) rolloutReached(rule *readinessv1alpha1.NodeReadinessGateRule, node *corev1.Node) bool {
+	if rule.Spec.Rollout == nil {
+		return true
+	}
+
+	r.rolloutsMutex.Lock()
+	defer r.rolloutsMutex.Unlock()
+
+	state, known := r.rollouts[rule.Name]
+	return known && state.generation == rule.Generation && state.reached(nodeRolloutKey(rule.Spec.Rollout, node))
+}
+
+// setRollout records the rollout progress of a rule
+func (r *ReadinessGateController) setRollout(ruleName string, state rolloutState) {
+	r.rolloutsMutex.Lock()
+	defer r.rolloutsMutex.Unlock()
+
+	if r.rollouts == nil {
+		r.rollouts = make(map[string]rolloutState)
+	}
+	r.rollouts[ruleName] = state
+}
+
+// forgetRollout drops the rollout progress of a rule
+func (r *ReadinessGateController) forgetRollout(ruleName string) {
+	r.rolloutsMutex.Lock()
+	defer r.rolloutsMutex.Unlock()
+
+	delete(r.rollouts, ruleName)
+}
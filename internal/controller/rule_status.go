@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// nodeOutcome is the latest result of evaluating a rule on a node, kept in
+// memory so that rule status counters do not need per-node status entries
+type nodeOutcome struct {
+	satisfied bool
+	gated     bool
+	pending   bool
+	failed    bool
+
+	// dryRun is the change the rule would make to the node, set while the
+	// rule is in dry run for it
+	dryRun *nodeSimulation
+}
+
+// ruleSummary aggregates the node outcomes of a rule
+type ruleSummary struct {
+	matched, ready, tainted, failed, pending int32
+}
+
+// recordNodeOutcome stores the result of evaluating a rule on a node
+func (r *ReadinessGateController) recordNodeOutcome(ruleName, nodeName string, outcome nodeOutcome) {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	r.ruleNodeOutcomes(ruleName)[nodeName] = outcome
+}
+
+// markNodeOutcomeFailed flags that a rule could not be evaluated or enforced on a node
+func (r *ReadinessGateController) markNodeOutcomeFailed(ruleName, nodeName string) {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	nodes := r.ruleNodeOutcomes(ruleName)
+	outcome := nodes[nodeName]
+	outcome.failed = true
+	nodes[nodeName] = outcome
+}
+
+// ruleNodeOutcomes returns the node outcomes of a rule, creating them on
+// first use. The caller must hold nodeOutcomesMutex.
+func (r *ReadinessGateController) ruleNodeOutcomes(ruleName string) map[string]nodeOutcome {
+	if r.nodeOutcomes == nil {
+		r.nodeOutcomes = make(map[string]map[string]nodeOutcome)
+	}
+	nodes, exists := r.nodeOutcomes[ruleName]
+	if !exists {
+		nodes = make(map[string]nodeOutcome)
+		r.nodeOutcomes[ruleName] = nodes
+	}
+	return nodes
+}
+
+// resetNodeOutcomes drops the node outcomes of a rule before all its nodes are re-evaluated
+func (r *ReadinessGateController) resetNodeOutcomes(ruleName string) {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	delete(r.nodeOutcomes, ruleName)
+	r.rebuiltOutcomes.Delete(ruleName)
+}
+
+// markNodeOutcomesRebuilt records that every node of a rule has been
+// evaluated since its outcomes were last reset
+func (r *ReadinessGateController) markNodeOutcomesRebuilt(ruleName string) {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	if r.rebuiltOutcomes == nil {
+		r.rebuiltOutcomes = sets.New[string]()
+	}
+	r.rebuiltOutcomes.Insert(ruleName)
+}
+
+// nodeOutcomesRebuilt checks if the node outcomes of a rule cover all its
+// nodes. Until then, e.g. while the first pass after a restart is running,
+// they only cover the nodes evaluated so far.
+func (r *ReadinessGateController) nodeOutcomesRebuilt(ruleName string) bool {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	return r.rebuiltOutcomes.Has(ruleName)
+}
+
+// retainNodeOutcomes drops the outcomes on a node of every rule that no longer applies to it
+func (r *ReadinessGateController) retainNodeOutcomes(nodeName string, applicableRules []*readinessv1alpha1.NodeReadinessGateRule) {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	applicable := make(map[string]bool, len(applicableRules))
+	for _, rule := range applicableRules {
+		applicable[rule.Name] = true
+	}
+	for ruleName, nodes := range r.nodeOutcomes {
+		if !applicable[ruleName] {
+			delete(nodes, nodeName)
+		}
+	}
+}
+
+// summarizeNodeOutcomes counts the node outcomes of a rule
+func (r *ReadinessGateController) summarizeNodeOutcomes(ruleName string) ruleSummary {
+	r.nodeOutcomesMutex.Lock()
+	defer r.nodeOutcomesMutex.Unlock()
+
+	var summary ruleSummary
+	for _, outcome := range r.nodeOutcomes[ruleName] {
+		summary.matched++
+		if outcome.satisfied && !outcome.gated {
+			summary.ready++
+		}
+		if outcome.gated {
+			summary.tainted++
+		}
+		if outcome.failed {
+			summary.failed++
+		}
+		if outcome.pending {
+			summary.pending++
+		}
+	}
+	return summary
+}
+
+// setRuleSummary writes the node counters and standard conditions of a rule
+// into its status. The persisted summary is kept until the node outcomes of
+// the rule have been rebuilt, so that a restart does not report partial counts.
+func (r *ReadinessGateController) setRuleSummary(rule *readinessv1alpha1.NodeReadinessGateRule) {
+	if !r.nodeOutcomesRebuilt(rule.Name) {
+		return
+	}
+	summary := r.summarizeNodeOutcomes(rule.Name)
+	status := &rule.Status
+	status.MatchedNodes = summary.matched
+	status.ReadyNodes = summary.ready
+	status.TaintedNodes = summary.tainted
+	status.FailedNodes = summary.failed
+
+	setCondition := func(conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: rule.Generation,
+		})
+	}
+
+	compiled := r.compiledRuleFor(rule)
+
+	readyMessage := fmt.Sprintf("%d of %d matched nodes ready", summary.ready, summary.matched)
+	if compiled.selectorErr != nil {
+		setCondition(readinessv1alpha1.RuleConditionReady, metav1.ConditionFalse, "InvalidNodeSelector",
+			"The node selector is invalid, so the rule applies to no node")
+	} else if summary.ready == summary.matched {
+		setCondition(readinessv1alpha1.RuleConditionReady, metav1.ConditionTrue, "AllNodesReady", readyMessage)
+	} else {
+		setCondition(readinessv1alpha1.RuleConditionReady, metav1.ConditionFalse, "NodesNotReady", readyMessage)
+	}
+
+	if rollout := status.Rollout; rule.Spec.Rollout != nil && rollout != nil &&
+		rollout.ObservedGeneration == rule.Generation && !rollout.Complete {
+		setCondition(readinessv1alpha1.RuleConditionProgressing, metav1.ConditionTrue, "RolloutInProgress",
+			fmt.Sprintf("Enforced on %d of %d matched nodes after %d rollout batches",
+				rollout.ReleasedNodes, summary.matched, rollout.Batches))
+	} else if summary.pending > 0 {
+		setCondition(readinessv1alpha1.RuleConditionProgressing, metav1.ConditionTrue, "TransitionsPending",
+			fmt.Sprintf("%d nodes waiting for a grace period or stableFor window", summary.pending))
+	} else {
+		setCondition(readinessv1alpha1.RuleConditionProgressing, metav1.ConditionFalse, "Settled",
+			"No node transitions pending")
+	}
+
+	if compiled.selectorErr != nil {
+		setCondition(readinessv1alpha1.RuleConditionDegraded, metav1.ConditionTrue, "InvalidNodeSelector",
+			compiled.selectorErr.Error())
+	} else if breaker, open := r.taintBreakerFor(rule.Name); open {
+		setCondition(readinessv1alpha1.RuleConditionDegraded, metav1.ConditionTrue, breaker.reason, breaker.message)
+	} else if invalid := compiled.invalidExpressions(); invalid > 0 {
+		setCondition(readinessv1alpha1.RuleConditionDegraded, metav1.ConditionTrue, "InvalidExpression",
+			fmt.Sprintf("%d expressions failed to compile", invalid))
+	} else if summary.failed > 0 {
+		setCondition(readinessv1alpha1.RuleConditionDegraded, metav1.ConditionTrue, "NodeFailures",
+			fmt.Sprintf("Failed to evaluate or enforce the rule on %d nodes", summary.failed))
+	} else {
+		setCondition(readinessv1alpha1.RuleConditionDegraded, metav1.ConditionFalse, "AsExpected",
+			"Rule is evaluated and enforced on all matched nodes")
+	}
+
+	switch {
+	case rule.Spec.DryRun:
+		setCondition(readinessv1alpha1.RuleConditionDryRun, metav1.ConditionTrue, "DryRunEnabled",
+			"Rule reports the changes it would make without applying them")
+	case r.isGlobalDryRun():
+		setCondition(readinessv1alpha1.RuleConditionDryRun, metav1.ConditionTrue, "GlobalDryRun",
+			fmt.Sprintf("Controller-wide dry run is enabled by %s; taint changes are frozen", r.globalDryRunSource()))
+	case r.canaryActive(rule):
+		canaryNodes := int32(0)
+		if canary := status.Canary; canary != nil && canary.ObservedGeneration == rule.Generation {
+			canaryNodes = canary.CanaryNodes
+		}
+		setCondition(readinessv1alpha1.RuleConditionDryRun, metav1.ConditionTrue, "CanaryEnforcement",
+			fmt.Sprintf("Rule adds taints to %d canary nodes only; the other nodes preview them in dry run", canaryNodes))
+	default:
+		setCondition(readinessv1alpha1.RuleConditionDryRun, metav1.ConditionFalse, "Enforcing",
+			"Rule is enforced")
+	}
+}
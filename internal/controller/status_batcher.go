@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+const (
+	// defaultStatusFlushInterval is how long rule status changes from node
+	// events are coalesced before they are written
+	defaultStatusFlushInterval = 2 * time.Second
+
+	// defaultStatusFlushMaxPending is the number of queued changes that
+	// triggers a flush before the interval expires
+	defaultStatusFlushMaxPending = 100
+)
+
+// pendingRuleStatus is a rule status change waiting to be written
+type pendingRuleStatus struct {
+	failingNodes []readinessv1alpha1.NodeFailure
+	since        time.Time
+}
+
+// statusBatcher coalesces the rule status changes made by node events, so
+// that a burst of node updates results in one status write per rule
+type statusBatcher struct {
+	mu         sync.Mutex
+	pending    map[string]pendingRuleStatus // ruleName -> latest change
+	queued     int
+	flushNow   chan struct{}
+	interval   time.Duration
+	maxPending int
+}
+
+func newStatusBatcher() *statusBatcher {
+	return &statusBatcher{
+		pending:    make(map[string]pendingRuleStatus),
+		flushNow:   make(chan struct{}, 1),
+		interval:   defaultStatusFlushInterval,
+		maxPending: defaultStatusFlushMaxPending,
+	}
+}
+
+// SetStatusBatching configures how long rule status changes from node events
+// are coalesced, and how many queued changes force an early flush
+func (r *ReadinessGateController) SetStatusBatching(interval time.Duration, maxPending int) {
+	batcher := r.batcher()
+	batcher.mu.Lock()
+	defer batcher.mu.Unlock()
+
+	if interval > 0 {
+		batcher.interval = interval
+	}
+	if maxPending > 0 {
+		batcher.maxPending = maxPending
+	}
+}
+
+// batcher returns the status batcher, creating it on first use
+func (r *ReadinessGateController) batcher() *statusBatcher {
+	r.statusBatcherOnce.Do(func() {
+		if r.statusBatcher == nil {
+			r.statusBatcher = newStatusBatcher()
+		}
+	})
+	return r.statusBatcher
+}
+
+// queueRuleStatus records the rule's status for the next flush. Later
+// changes to the same rule replace earlier ones.
+func (r *ReadinessGateController) queueRuleStatus(rule *readinessv1alpha1.NodeReadinessGateRule) {
+	failingNodes := r.failingNodesSnapshot(rule)
+
+	batcher := r.batcher()
+	batcher.mu.Lock()
+	defer batcher.mu.Unlock()
+
+	since := time.Now()
+	if existing, exists := batcher.pending[rule.Name]; exists {
+		since = existing.since
+	}
+	batcher.pending[rule.Name] = pendingRuleStatus{
+		failingNodes: failingNodes,
+		since:        since,
+	}
+
+	batcher.queued++
+	if batcher.queued >= batcher.maxPending {
+		select {
+		case batcher.flushNow <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// flushRuleStatuses writes all queued rule status changes. Changes that fail
+// to be written are queued again unless a newer change replaced them.
+func (r *ReadinessGateController) flushRuleStatuses(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+	batcher := r.batcher()
+
+	batcher.mu.Lock()
+	pending := batcher.pending
+	batcher.pending = make(map[string]pendingRuleStatus)
+	batcher.queued = 0
+	batcher.mu.Unlock()
+
+	for ruleName, status := range pending {
+		if err := r.patchRuleStatus(ctx, ruleName, status.failingNodes); err != nil {
+			log.Error(err, "Failed to flush rule status", "rule", ruleName)
+
+			batcher.mu.Lock()
+			if _, replaced := batcher.pending[ruleName]; !replaced {
+				batcher.pending[ruleName] = status
+			}
+			batcher.mu.Unlock()
+			continue
+		}
+		ruleStatusFlushLatency.Observe(time.Since(status.since).Seconds())
+	}
+}
+
+// patchRuleStatus writes the failing node sample, the node summary and the
+// dry run plan of a rule with a merge patch, so that concurrent writers do
+// not conflict
+func (r *ReadinessGateController) patchRuleStatus(ctx context.Context, ruleName string, failingNodes []readinessv1alpha1.NodeFailure) error {
+	rule := &readinessv1alpha1.NodeReadinessGateRule{}
+	if err := r.Get(ctx, client.ObjectKey{Name: ruleName}, rule); err != nil {
+		// The rule was deleted since the change was queued
+		return client.IgnoreNotFound(err)
+	}
+
+	base := rule.DeepCopy()
+	rule.Status.FailingNodes = failingNodes
+	if r.reportsDryRun(rule) {
+		r.reportDryRun(ctx, rule)
+	}
+	r.setRuleSummary(rule)
+	if equality.Semantic.DeepEqual(base.Status, rule.Status) {
+		ruleStatusWrites.WithLabelValues("unchanged").Inc()
+		return nil
+	}
+
+	// A merge patch without a resourceVersion does not conflict
+	if err := r.Status().Patch(ctx, rule, client.MergeFrom(base)); err != nil {
+		ruleStatusWrites.WithLabelValues("error").Inc()
+		return err
+	}
+	ruleStatusWrites.WithLabelValues("success").Inc()
+	return nil
+}
+
+// recordStatusWriteError counts a failed rule status write
+func recordStatusWriteError(err error) {
+	if apierrors.IsConflict(err) {
+		ruleStatusWrites.WithLabelValues("conflict").Inc()
+	} else {
+		ruleStatusWrites.WithLabelValues("error").Inc()
+	}
+}
+
+// runStatusFlusher flushes queued rule status changes every interval, or
+// early once enough changes are queued, until the context is cancelled
+func (r *ReadinessGateController) runStatusFlusher(ctx context.Context) error {
+	batcher := r.batcher()
+	batcher.mu.Lock()
+	interval := batcher.interval
+	batcher.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Write what is left before shutting down
+			r.flushRuleStatuses(context.WithoutCancel(ctx))
+			return nil
+		case <-ticker.C:
+			r.flushRuleStatuses(ctx)
+		case <-batcher.flushNow:
+			r.flushRuleStatuses(ctx)
+		}
+	}
+}
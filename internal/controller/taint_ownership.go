@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+)
+
+// managedTaintsAnnotation records, per rule, the taints the controller added
+// to a node. Only these taints are ever removed by the controller.
+const managedTaintsAnnotation = "readiness.k8s.io/managed-taints"
+
+// taintOwner lists the taints a rule added to a node, as "key:effect"
+type taintOwner struct {
+	UID    types.UID `json:"uid"`
+	Taints []string  `json:"taints"`
+}
+
+// taintID identifies a taint by key and effect
+func taintID(key string, effect corev1.TaintEffect) string {
+	return key + ":" + string(effect)
+}
+
+// getManagedTaints parses the ownership annotation of a node. A missing or
+// malformed annotation means the controller owns no taints on the node.
+func getManagedTaints(node *corev1.Node) map[string]taintOwner {
+	owners := make(map[string]taintOwner)
+	if value, exists := node.Annotations[managedTaintsAnnotation]; exists {
+		_ = json.Unmarshal([]byte(value), &owners)
+	}
+	return owners
+}
+
+// setManagedTaints writes the ownership annotation, dropping it once empty
+func setManagedTaints(node *corev1.Node, owners map[string]taintOwner) {
+	if len(owners) == 0 {
+		delete(node.Annotations, managedTaintsAnnotation)
+		return
+	}
+
+	value, err := json.Marshal(owners)
+	if err != nil {
+		return
+	}
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[managedTaintsAnnotation] = string(value)
+}
+
+// ownsTaint checks if a taint on the node was added by the rule. With
+// adoptExisting, every taint matching the rule's spec is treated as owned.
+func ownsTaint(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule, taintSpec readinessv1alpha1.TaintSpec) bool {
+	if rule.Spec.AdoptExisting {
+		return true
+	}
+	owner, exists := getManagedTaints(node)[rule.Name]
+	return exists && owner.UID == rule.UID && slices.Contains(owner.Taints, taintID(taintSpec.Key, taintSpec.Effect))
+}
+
+// recordOwnedTaints marks taints as added by the rule
+func recordOwnedTaints(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule, taintSpecs []readinessv1alpha1.TaintSpec) {
+	if len(taintSpecs) == 0 {
+		return
+	}
+
+	owners := getManagedTaints(node)
+	owner := owners[rule.Name]
+	if owner.UID != rule.UID {
+		owner = taintOwner{UID: rule.UID}
+	}
+	for _, taintSpec := range taintSpecs {
+		if id := taintID(taintSpec.Key, taintSpec.Effect); !slices.Contains(owner.Taints, id) {
+			owner.Taints = append(owner.Taints, id)
+		}
+	}
+	owners[rule.Name] = owner
+	setManagedTaints(node, owners)
+}
+
+// forgetOwnedTaints drops the rule's ownership of the given taints
+func forgetOwnedTaints(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule, taintSpecs []readinessv1alpha1.TaintSpec) {
+	owners := getManagedTaints(node)
+	owner, exists := owners[rule.Name]
+	if !exists {
+		return
+	}
+	owner.Taints = slices.DeleteFunc(owner.Taints, func(id string) bool {
+		for _, taintSpec := range taintSpecs {
+			if id == taintID(taintSpec.Key, taintSpec.Effect) {
+				return true
+			}
+		}
+		return false
+	})
+	if len(owner.Taints) == 0 {
+		delete(owners, rule.Name)
+	} else {
+		owners[rule.Name] = owner
+	}
+	setManagedTaints(node, owners)
+}
+
+// hasTaintOwner checks if the rule has a taint ownership record on the node
+func hasTaintOwner(node *corev1.Node, ruleName string) bool {
+	_, exists := getManagedTaints(node)[ruleName]
+	return exists
+}
+
+// forgetTaintOwner drops the rule's record from a node the rule no longer
+// manages, reporting whether there was one
+func forgetTaintOwner(node *corev1.Node, rule *readinessv1alpha1.NodeReadinessGateRule) bool {
+	if !hasTaintOwner(node, rule.Name) {
+		return false
+	}
+	owners := getManagedTaints(node)
+	delete(owners, rule.Name)
+	setManagedTaints(node, owners)
+	return true
+}
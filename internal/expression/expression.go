@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expression compiles and evaluates the CEL expressions that
+// NodeReadinessGateRules use to gate on arbitrary Node fields.
+package expression
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NodeVariable is the name under which the Node is exposed to expressions
+const NodeVariable = "node"
+
+const (
+	// CostLimit bounds the cost of evaluating an expression, the same as the
+	// per-expression limit Kubernetes applies to CEL validation rules
+	CostLimit uint64 = 1000000
+
+	// maxNodeFieldSize is the number of entries or characters assumed for
+	// any field of the Node when estimating the cost of an expression
+	maxNodeFieldSize uint64 = 1024
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+// newEnv returns the shared CEL environment. The node is declared as a
+// dynamic map so that any field of the Node object can be referenced.
+func newEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable(NodeVariable, cel.MapType(cel.StringType, cel.DynType)),
+		)
+	})
+	return env, envErr
+}
+
+// Compile parses and type-checks an expression and returns a program ready
+// for evaluation. Expressions must evaluate to a boolean. Node fields are
+// dynamic, so a bare field reference such as node.spec.unschedulable is
+// rejected and must be compared explicitly.
+func Compile(expr string) (cel.Program, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	if outputType := ast.OutputType(); !outputType.IsExactType(cel.BoolType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, got %s", outputType)
+	}
+
+	return env.Program(ast, cel.CostLimit(CostLimit))
+}
+
+// EstimateCost returns the worst case cost of evaluating an expression, to
+// be compared against CostLimit
+func EstimateCost(expr string) (uint64, error) {
+	env, err := newEnv()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return 0, issues.Err()
+	}
+
+	cost, err := env.EstimateCost(ast, nodeSizeEstimator{})
+	if err != nil {
+		return 0, err
+	}
+	return cost.Max, nil
+}
+
+// nodeSizeEstimator bounds the size of Node fields, which are otherwise
+// unknown to the cost estimate
+type nodeSizeEstimator struct{}
+
+func (nodeSizeEstimator) EstimateSize(checker.AstNode) *checker.SizeEstimate {
+	return &checker.SizeEstimate{Min: 0, Max: maxNodeFieldSize}
+}
+
+func (nodeSizeEstimator) EstimateCallCost(string, string, *checker.AstNode, []checker.AstNode) *checker.CallEstimate {
+	return nil
+}
+
+// NodeToInput converts a node into the activation passed to Evaluate. Resource
+// quantities in status.capacity and status.allocatable are exposed as
+// integers, so expressions like node.status.allocatable['nvidia.com/gpu'] > 0
+// work as expected.
+func NodeToInput(node *corev1.Node) (map[string]any, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert node: %w", err)
+	}
+
+	if status, ok := obj["status"].(map[string]any); ok {
+		status["capacity"] = quantitiesToInt(node.Status.Capacity)
+		status["allocatable"] = quantitiesToInt(node.Status.Allocatable)
+	}
+
+	return map[string]any{NodeVariable: obj}, nil
+}
+
+// Evaluate runs a compiled program against the input built by NodeToInput
+func Evaluate(prg cel.Program, input map[string]any) (bool, error) {
+	out, _, err := prg.Eval(input)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %T, expected bool", out.Value())
+	}
+	return result, nil
+}
+
+// quantitiesToInt converts a resource list into resource name -> integer value
+func quantitiesToInt(resources corev1.ResourceList) map[string]any {
+	values := make(map[string]any, len(resources))
+	for name, quantity := range resources {
+		values[string(name)] = quantity.Value()
+	}
+	return values
+}
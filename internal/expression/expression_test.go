@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expression
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpression(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Expression Suite")
+}
+
+var _ = Describe("CEL node expressions", func() {
+	var node *corev1.Node
+
+	BeforeEach(func() {
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "gpu-node",
+				Labels: map[string]string{"accelerator": "nvidia"},
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					"nvidia.com/gpu":      resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+				Conditions: []corev1.NodeCondition{
+					{Type: "DriverReady", Status: corev1.ConditionTrue, Reason: "Configured"},
+				},
+			},
+		}
+	})
+
+	evaluate := func(expr string) (bool, error) {
+		prg, err := Compile(expr)
+		Expect(err).NotTo(HaveOccurred())
+		input, err := NodeToInput(node)
+		Expect(err).NotTo(HaveOccurred())
+		return Evaluate(prg, input)
+	}
+
+	It("should expose resource quantities as integers", func() {
+		Expect(evaluate("node.status.allocatable['nvidia.com/gpu'] > 0")).To(BeTrue())
+		Expect(evaluate("node.status.allocatable['memory'] == 17179869184")).To(BeTrue())
+	})
+
+	It("should evaluate labels and condition fields", func() {
+		Expect(evaluate("'accelerator' in node.metadata.labels")).To(BeTrue())
+		Expect(evaluate("node.status.conditions.exists(c, c.type == 'DriverReady' && c.reason == 'Configured')")).To(BeTrue())
+		Expect(evaluate("node.metadata.labels['accelerator'] == 'amd'")).To(BeFalse())
+	})
+
+	It("should report evaluation errors for missing fields", func() {
+		_, err := evaluate("node.status.allocatable['amd.com/gpu'] > 0")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject invalid expressions at compile time", func() {
+		_, err := Compile("node.status.allocatable[")
+		Expect(err).To(HaveOccurred())
+
+		_, err = Compile("pod.metadata.name == 'x'")
+		Expect(err).To(HaveOccurred())
+
+		_, err = Compile("'not a bool'")
+		Expect(err).To(MatchError(ContainSubstring("must evaluate to a bool")))
+
+		_, err = Compile("node.metadata.labels['accelerator']")
+		Expect(err).To(MatchError(ContainSubstring("must evaluate to a bool")))
+	})
+
+	It("should estimate expressions within the cost limit", func() {
+		cost, err := EstimateCost("node.status.conditions.exists(c, c.type == 'DriverReady' && c.status == 'True')")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cost).To(BeNumerically("<=", CostLimit))
+
+		cost, err = EstimateCost("node.status.conditions.all(a, node.status.conditions.all(b, node.status.conditions.exists(c, a.type == b.type || b.type == c.type)))")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cost).To(BeNumerically(">", CostLimit))
+	})
+
+	It("should stop evaluations that exceed the cost limit", func() {
+		for i := range 2000 {
+			node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+				Type: corev1.NodeConditionType(fmt.Sprintf("Condition%d", i)), Status: corev1.ConditionTrue,
+			})
+		}
+		_, err := evaluate("node.status.conditions.all(a, node.status.conditions.all(b, a.status == b.status))")
+		Expect(err).To(MatchError(ContainSubstring("cost limit")))
+	})
+})
@@ -22,6 +22,8 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	readinessv1alpha1 "github.com/ajaysundark/node-readiness-gate-controller/api/v1alpha1"
+	"github.com/ajaysundark/node-readiness-gate-controller/internal/expression"
 )
 
 // NodeReadinessGateRuleWebhook validates NodeReadinessGateRule resources
@@ -52,8 +55,9 @@ func (w *NodeReadinessGateRuleWebhook) validateNodeReadinessGateRule(ctx context
 	// Validate basic fields
 	allErrs = append(allErrs, w.validateSpec(rule.Spec)...)
 
-	// Check for conflicting rules (same taint key)
+	// Check for conflicting rules (same taint key, label key or condition type)
 	allErrs = append(allErrs, w.validateTaintConflicts(ctx, rule, isUpdate)...)
+	allErrs = append(allErrs, w.validateActionConflicts(ctx, rule, isUpdate)...)
 
 	return allErrs
 }
@@ -64,27 +68,100 @@ func (w *NodeReadinessGateRuleWebhook) validateSpec(spec readinessv1alpha1.NodeR
 	specField := field.NewPath("spec")
 
 	// Validate conditions
-	if len(spec.Conditions) == 0 {
-		allErrs = append(allErrs, field.Required(specField.Child("conditions"), "at least one condition is required"))
+	if len(spec.Conditions) == 0 && len(spec.ConditionGroups) == 0 && len(spec.Expressions) == 0 {
+		allErrs = append(allErrs, field.Required(specField.Child("conditions"), "at least one condition, condition group or expression is required"))
 	}
 
 	for i, condition := range spec.Conditions {
-		condField := specField.Child("conditions").Index(i)
-		if condition.Type == "" {
-			allErrs = append(allErrs, field.Required(condField.Child("type"), "condition type cannot be empty"))
+		allErrs = append(allErrs, validateConditionRequirement(specField.Child("conditions").Index(i), condition)...)
+	}
+
+	// Validate condition groups
+	groupNames := make(map[string]bool)
+	for i, group := range spec.ConditionGroups {
+		groupField := specField.Child("conditionGroups").Index(i)
+		if group.Name != "" && groupNames[group.Name] {
+			allErrs = append(allErrs, field.Duplicate(groupField.Child("name"), group.Name))
+		}
+		groupNames[group.Name] = true
+
+		memberCount := len(group.Conditions) + len(group.Groups)
+		allErrs = append(allErrs, validateConditionGroup(groupField, group.Name, group.Operator, group.MinSatisfied, memberCount)...)
+		for j, condition := range group.Conditions {
+			allErrs = append(allErrs, validateConditionRequirement(groupField.Child("conditions").Index(j), condition)...)
+		}
+
+		subGroupNames := make(map[string]bool)
+		for j, subGroup := range group.Groups {
+			subGroupField := groupField.Child("groups").Index(j)
+			if subGroup.Name != "" && subGroupNames[subGroup.Name] {
+				allErrs = append(allErrs, field.Duplicate(subGroupField.Child("name"), subGroup.Name))
+			}
+			subGroupNames[subGroup.Name] = true
+
+			allErrs = append(allErrs, validateConditionGroup(subGroupField, subGroup.Name, subGroup.Operator, subGroup.MinSatisfied, len(subGroup.Conditions))...)
+			for k, condition := range subGroup.Conditions {
+				allErrs = append(allErrs, validateConditionRequirement(subGroupField.Child("conditions").Index(k), condition)...)
+			}
+		}
+	}
+
+	// Validate expressions
+	expressionNames := make(map[string]bool)
+	for i, expr := range spec.Expressions {
+		exprField := specField.Child("expressions").Index(i)
+		if expr.Name == "" {
+			allErrs = append(allErrs, field.Required(exprField.Child("name"), "expression name cannot be empty"))
+		} else if expressionNames[expr.Name] {
+			allErrs = append(allErrs, field.Duplicate(exprField.Child("name"), expr.Name))
 		}
-		if condition.RequiredStatus == "" {
-			allErrs = append(allErrs, field.Required(condField.Child("requiredStatus"), "required status cannot be empty"))
+		expressionNames[expr.Name] = true
+
+		if expr.Expression == "" {
+			allErrs = append(allErrs, field.Required(exprField.Child("expression"), "expression cannot be empty"))
+		} else if _, err := expression.Compile(expr.Expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(exprField.Child("expression"), expr.Expression, err.Error()))
+		} else if cost, err := expression.EstimateCost(expr.Expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(exprField.Child("expression"), expr.Expression, err.Error()))
+		} else if cost > expression.CostLimit {
+			allErrs = append(allErrs, field.Invalid(exprField.Child("expression"), expr.Expression,
+				fmt.Sprintf("estimated cost %d exceeds the limit of %d", cost, expression.CostLimit)))
 		}
 	}
 
-	// Validate taint
-	taintField := specField.Child("taint")
-	if spec.Taint.Key == "" {
-		allErrs = append(allErrs, field.Required(taintField.Child("key"), "taint key cannot be empty"))
+	// Validate taints
+	taints, taintFields := ruleTaints(spec)
+	if len(taints) == 0 && !hasGateActions(spec.Actions) {
+		allErrs = append(allErrs, field.Required(specField.Child("taints"), "at least one taint or action is required"))
 	}
-	if spec.Taint.Effect == "" {
-		allErrs = append(allErrs, field.Required(taintField.Child("effect"), "taint effect cannot be empty"))
+	seenTaints := make(map[string]bool)
+	for i, taint := range taints {
+		if taint.Key == "" {
+			allErrs = append(allErrs, field.Required(taintFields[i].Child("key"), "taint key cannot be empty"))
+		}
+		if taint.Effect == "" {
+			allErrs = append(allErrs, field.Required(taintFields[i].Child("effect"), "taint effect cannot be empty"))
+		}
+
+		id := taint.Key + ":" + string(taint.Effect)
+		if seenTaints[id] {
+			allErrs = append(allErrs, field.Duplicate(taintFields[i], id))
+		}
+		seenTaints[id] = true
+	}
+
+	// Validate gate actions
+	if spec.Actions != nil {
+		actionsField := specField.Child("actions")
+		if label := spec.Actions.Label; label != nil {
+			labelField := actionsField.Child("label")
+			for _, msg := range validation.IsQualifiedName(label.Key) {
+				allErrs = append(allErrs, field.Invalid(labelField.Child("key"), label.Key, msg))
+			}
+			for _, msg := range validation.IsValidLabelValue(label.Value) {
+				allErrs = append(allErrs, field.Invalid(labelField.Child("value"), label.Value, msg))
+			}
+		}
 	}
 
 	// Validate enforcement mode
@@ -97,6 +174,181 @@ func (w *NodeReadinessGateRuleWebhook) validateSpec(spec readinessv1alpha1.NodeR
 		))
 	}
 
+	// Validate re-arm policy
+	if rearmOn := spec.RearmOn; rearmOn != nil {
+		rearmField := specField.Child("rearmOn")
+		if spec.EnforcementMode != readinessv1alpha1.EnforcementModeBootstrapOnly {
+			allErrs = append(allErrs, field.Invalid(
+				rearmField,
+				spec.EnforcementMode,
+				"rearmOn is only supported for bootstrap-only rules",
+			))
+		}
+		for i, key := range rearmOn.Labels {
+			for _, msg := range validation.IsQualifiedName(key) {
+				allErrs = append(allErrs, field.Invalid(rearmField.Child("labels").Index(i), key, msg))
+			}
+		}
+	}
+
+	// Validate grace period
+	if spec.GracePeriod != nil && spec.GracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(
+			specField.Child("gracePeriod"),
+			spec.GracePeriod.Duration.String(),
+			"grace period cannot be negative",
+		))
+	}
+
+	// Validate stable window
+	if spec.StableFor != nil && spec.StableFor.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(
+			specField.Child("stableFor"),
+			spec.StableFor.Duration.String(),
+			"stableFor cannot be negative",
+		))
+	}
+
+	// Validate blast radius limit
+	if spec.MaxUnavailable != nil {
+		maxUnavailableField := specField.Child("maxUnavailable")
+		if value, err := intstr.GetScaledValueFromIntOrPercent(spec.MaxUnavailable, 100, true); err != nil {
+			allErrs = append(allErrs, field.Invalid(maxUnavailableField, spec.MaxUnavailable.String(), err.Error()))
+		} else if value < 0 {
+			allErrs = append(allErrs, field.Invalid(maxUnavailableField, spec.MaxUnavailable.String(),
+				"maxUnavailable cannot be negative"))
+		} else if spec.MaxUnavailable.Type == intstr.String && value > 100 {
+			allErrs = append(allErrs, field.Invalid(maxUnavailableField, spec.MaxUnavailable.String(),
+				"maxUnavailable percentage cannot exceed 100%"))
+		}
+	}
+
+	// Validate canary strategy
+	if canary := spec.Canary; canary != nil {
+		canaryField := specField.Child("canary")
+		if canary.Percentage == nil && canary.Selector == nil {
+			allErrs = append(allErrs, field.Required(canaryField, "one of percentage or selector is required"))
+		} else if canary.Percentage != nil && canary.Selector != nil {
+			allErrs = append(allErrs, field.Forbidden(canaryField.Child("selector"), "cannot be combined with percentage"))
+		}
+		if canary.Percentage != nil && (*canary.Percentage < 0 || *canary.Percentage > 100) {
+			allErrs = append(allErrs, field.Invalid(canaryField.Child("percentage"), *canary.Percentage,
+				"percentage must be between 0 and 100"))
+		}
+		if canary.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(canary.Selector); err != nil {
+				allErrs = append(allErrs, field.Invalid(canaryField.Child("selector"), canary.Selector, err.Error()))
+			}
+		}
+		if canary.PromoteAfter != nil && canary.PromoteAfter.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(canaryField.Child("promoteAfter"),
+				canary.PromoteAfter.Duration.String(), "promoteAfter must be positive"))
+		}
+	}
+
+	// Validate rollout strategy
+	if rollout := spec.Rollout; rollout != nil {
+		rolloutField := specField.Child("rollout")
+		batchSize, err := intstr.GetScaledValueFromIntOrPercent(&rollout.BatchSize, 100, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(rolloutField.Child("batchSize"), rollout.BatchSize.String(), err.Error()))
+		} else if batchSize <= 0 || (rollout.BatchSize.Type == intstr.String && batchSize > 100) {
+			allErrs = append(allErrs, field.Invalid(rolloutField.Child("batchSize"), rollout.BatchSize.String(),
+				"batch size must be a positive number or a percentage between 1% and 100%"))
+		}
+		if rollout.Pause.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(rolloutField.Child("pause"), rollout.Pause.Duration.String(),
+				"pause must be positive"))
+		}
+		if rollout.OrderBy != "" {
+			for _, msg := range validation.IsQualifiedName(rollout.OrderBy) {
+				allErrs = append(allErrs, field.Invalid(rolloutField.Child("orderBy"), rollout.OrderBy, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateConditionRequirement validates a single condition requirement
+func validateConditionRequirement(condField *field.Path, condition readinessv1alpha1.ConditionRequirement) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if condition.Type == "" {
+		allErrs = append(allErrs, field.Required(condField.Child("type"), "condition type cannot be empty"))
+	}
+	if condition.RequiredStatus == "" {
+		allErrs = append(allErrs, field.Required(condField.Child("requiredStatus"), "required status cannot be empty"))
+	}
+
+	if condition.MaxAge != nil && condition.MaxAge.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(condField.Child("maxAge"), condition.MaxAge.Duration.String(), "must be positive"))
+	}
+
+	switch condition.MissingPolicy {
+	case "", readinessv1alpha1.MissingPolicyTreatAsUnsatisfied, readinessv1alpha1.MissingPolicyTreatAsSatisfied:
+		if condition.MissingTimeout != nil {
+			allErrs = append(allErrs, field.Forbidden(condField.Child("missingTimeout"), "only allowed with missingPolicy TreatAsSatisfiedAfter"))
+		}
+	case readinessv1alpha1.MissingPolicyTreatAsSatisfiedAfter:
+		if condition.MissingTimeout == nil {
+			allErrs = append(allErrs, field.Required(condField.Child("missingTimeout"), "required with missingPolicy TreatAsSatisfiedAfter"))
+		} else if condition.MissingTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(condField.Child("missingTimeout"), condition.MissingTimeout.Duration.String(), "must be positive"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			condField.Child("missingPolicy"),
+			condition.MissingPolicy,
+			[]string{
+				string(readinessv1alpha1.MissingPolicyTreatAsUnsatisfied),
+				string(readinessv1alpha1.MissingPolicyTreatAsSatisfied),
+				string(readinessv1alpha1.MissingPolicyTreatAsSatisfiedAfter),
+			},
+		))
+	}
+
+	return allErrs
+}
+
+// validateConditionGroup validates the fields shared by condition groups and nested groups
+func validateConditionGroup(
+	groupField *field.Path,
+	name string,
+	operator readinessv1alpha1.ConditionGroupOperator,
+	minSatisfied, memberCount int,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if name == "" {
+		allErrs = append(allErrs, field.Required(groupField.Child("name"), "group name cannot be empty"))
+	}
+	if memberCount == 0 {
+		allErrs = append(allErrs, field.Required(groupField.Child("conditions"), "group must contain at least one condition or group"))
+	}
+
+	switch operator {
+	case readinessv1alpha1.ConditionGroupOperatorAll, readinessv1alpha1.ConditionGroupOperatorAny:
+	case readinessv1alpha1.ConditionGroupOperatorAtLeast:
+		if minSatisfied < 1 || minSatisfied > memberCount {
+			allErrs = append(allErrs, field.Invalid(
+				groupField.Child("minSatisfied"),
+				minSatisfied,
+				fmt.Sprintf("must be between 1 and the number of group members (%d)", memberCount),
+			))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			groupField.Child("operator"),
+			operator,
+			[]string{
+				string(readinessv1alpha1.ConditionGroupOperatorAll),
+				string(readinessv1alpha1.ConditionGroupOperatorAny),
+				string(readinessv1alpha1.ConditionGroupOperatorAtLeast),
+			},
+		))
+	}
+
 	return allErrs
 }
 
@@ -104,33 +356,63 @@ func (w *NodeReadinessGateRuleWebhook) validateSpec(spec readinessv1alpha1.NodeR
 func (w *NodeReadinessGateRuleWebhook) validateTaintConflicts(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, isUpdate bool) field.ErrorList {
 	var allErrs field.ErrorList
 
-	// List all existing rules
-	ruleList := &readinessv1alpha1.NodeReadinessGateRuleList{}
-	if err := w.List(ctx, ruleList); err != nil {
-		// If we can't list rules, allow the operation but log the issue
-		ctrl.Log.Error(err, "Failed to list rules for conflict validation")
-		return allErrs
+	taints, taintFields := ruleTaints(rule.Spec)
+
+	for _, existingRule := range w.overlappingRules(ctx, rule, isUpdate) {
+		// Check every taint for the same key and effect
+		existingTaints := existingRule.Spec.GetTaints()
+		for i, taint := range taints {
+			for _, existingTaint := range existingTaints {
+				if existingTaint.Key != taint.Key || existingTaint.Effect != taint.Effect {
+					continue
+				}
+				allErrs = append(allErrs, field.Invalid(
+					taintFields[i].Child("key"),
+					taint.Key,
+					fmt.Sprintf("conflicts with existing rule '%s' - same taint key '%s' and effect '%s' with overlapping node selectors",
+						existingRule.Name, taint.Key, taint.Effect),
+				))
+			}
+		}
 	}
 
-	taintField := field.NewPath("spec", "taint", "key")
+	return allErrs
+}
 
-	for _, existingRule := range ruleList.Items {
-		// Skip self when updating
-		if isUpdate && existingRule.Name == rule.Name {
+// validateActionConflicts checks for conflicting rules that set the same
+// label key or write the same node condition
+func (w *NodeReadinessGateRuleWebhook) validateActionConflicts(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, isUpdate bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	actions := rule.Spec.Actions
+	if actions == nil || (actions.Label == nil && actions.Condition == nil) {
+		return allErrs
+	}
+	actionsField := field.NewPath("spec", "actions")
+
+	for _, existingRule := range w.overlappingRules(ctx, rule, isUpdate) {
+		existingActions := existingRule.Spec.Actions
+		if existingActions == nil {
 			continue
 		}
 
-		// Check for same taint key and effect
-		if existingRule.Spec.Taint.Key == rule.Spec.Taint.Key &&
-			existingRule.Spec.Taint.Effect == rule.Spec.Taint.Effect {
+		if actions.Label != nil && existingActions.Label != nil && actions.Label.Key == existingActions.Label.Key {
+			allErrs = append(allErrs, field.Invalid(
+				actionsField.Child("label", "key"),
+				actions.Label.Key,
+				fmt.Sprintf("conflicts with existing rule '%s' - same label key '%s' with overlapping node selectors",
+					existingRule.Name, actions.Label.Key),
+			))
+		}
 
-			// Check if node selectors overlap
-			if w.nodSelectorsOverlap(rule.Spec.NodeSelector, existingRule.Spec.NodeSelector) {
+		if actions.Condition != nil && existingActions.Condition != nil {
+			conditionType := gateConditionType(rule.Name, actions.Condition)
+			if conditionType == gateConditionType(existingRule.Name, existingActions.Condition) {
 				allErrs = append(allErrs, field.Invalid(
-					taintField,
-					rule.Spec.Taint.Key,
-					fmt.Sprintf("conflicts with existing rule '%s' - same taint key '%s' and effect '%s' with overlapping node selectors",
-						existingRule.Name, rule.Spec.Taint.Key, rule.Spec.Taint.Effect),
+					actionsField.Child("condition", "type"),
+					conditionType,
+					fmt.Sprintf("conflicts with existing rule '%s' - same condition type '%s' with overlapping node selectors",
+						existingRule.Name, conditionType),
 				))
 			}
 		}
@@ -139,6 +421,60 @@ func (w *NodeReadinessGateRuleWebhook) validateTaintConflicts(ctx context.Contex
 	return allErrs
 }
 
+// overlappingRules lists the other rules whose node selectors overlap the
+// rule's. If the rules cannot be listed, no rule is returned so that the
+// operation is allowed.
+func (w *NodeReadinessGateRuleWebhook) overlappingRules(ctx context.Context, rule *readinessv1alpha1.NodeReadinessGateRule, isUpdate bool) []readinessv1alpha1.NodeReadinessGateRule {
+	ruleList := &readinessv1alpha1.NodeReadinessGateRuleList{}
+	if err := w.List(ctx, ruleList); err != nil {
+		ctrl.Log.Error(err, "Failed to list rules for conflict validation")
+		return nil
+	}
+
+	var rules []readinessv1alpha1.NodeReadinessGateRule
+	for _, existingRule := range ruleList.Items {
+		// Skip self when updating
+		if isUpdate && existingRule.Name == rule.Name {
+			continue
+		}
+
+		// Rules on disjoint sets of nodes never conflict
+		if !w.nodSelectorsOverlap(rule.Spec.NodeSelector, existingRule.Spec.NodeSelector) {
+			continue
+		}
+		rules = append(rules, existingRule)
+	}
+	return rules
+}
+
+// gateConditionType returns the node condition type written by a rule's
+// condition action, defaulting to ReadinessGates/<rule name> like the controller
+func gateConditionType(ruleName string, condition *readinessv1alpha1.ConditionAction) string {
+	if condition.Type != "" {
+		return condition.Type
+	}
+	return "ReadinessGates/" + ruleName
+}
+
+// hasGateActions checks if any gate action other than tainting is configured
+func hasGateActions(actions *readinessv1alpha1.GateActions) bool {
+	return actions != nil && (actions.Cordon || actions.Label != nil || actions.Condition != nil)
+}
+
+// ruleTaints returns the taints of a rule along with the path of the field
+// each one is declared in
+func ruleTaints(spec readinessv1alpha1.NodeReadinessGateRuleSpec) ([]readinessv1alpha1.TaintSpec, []*field.Path) {
+	taints := spec.GetTaints()
+	paths := make([]*field.Path, 0, len(taints))
+	if spec.Taint != nil {
+		paths = append(paths, field.NewPath("spec", "taint"))
+	}
+	for i := range spec.Taints {
+		paths = append(paths, field.NewPath("spec", "taints").Index(i))
+	}
+	return taints, paths
+}
+
 // nodeSelectorsOverlap checks if two node selectors overlap
 func (w *NodeReadinessGateRuleWebhook) nodSelectorsOverlap(selector1, selector2 *metav1.LabelSelector) bool {
 	// If either selector is nil, it matches all nodes - so they overlap
@@ -19,12 +19,14 @@ package webhook
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -62,7 +64,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 			}
 
 			allErrs := webhook.validateSpec(rule.Spec)
-			Expect(allErrs).To(HaveLen(4)) // conditions, taint.key, taint.effect, enforcementMode
+			Expect(allErrs).To(HaveLen(3)) // conditions, taints, enforcementMode
 
 			// Check specific errors
 			var foundErrors []string
@@ -71,11 +73,71 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 			}
 
 			Expect(foundErrors).To(ContainElement("spec.conditions"))
-			Expect(foundErrors).To(ContainElement("spec.taint.key"))
-			Expect(foundErrors).To(ContainElement("spec.taint.effect"))
+			Expect(foundErrors).To(ContainElement("spec.taints"))
 			Expect(foundErrors).To(ContainElement("spec.enforcementMode"))
 		})
 
+		It("should validate every taint in the list", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taint: &readinessv1alpha1.TaintSpec{Key: "gate", Effect: corev1.TaintEffectNoSchedule},
+					Taints: []readinessv1alpha1.TaintSpec{
+						{Key: "gate", Effect: corev1.TaintEffectNoExecute},
+						{Key: "", Effect: corev1.TaintEffectPreferNoSchedule},
+						{Key: "gate", Effect: corev1.TaintEffectNoSchedule},
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			allErrs := webhook.validateSpec(rule.Spec)
+
+			var foundErrors []string
+			for _, err := range allErrs {
+				foundErrors = append(foundErrors, err.Field)
+			}
+			Expect(foundErrors).To(ConsistOf(
+				"spec.taints[1].key",
+				"spec.taints[2]",
+			))
+		})
+
+		It("should accept gate actions in place of taints", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Actions: &readinessv1alpha1.GateActions{
+						Label: &readinessv1alpha1.LabelAction{Key: "batch.example.com/ready", Value: "false"},
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+			Expect(webhook.validateSpec(rule.Spec)).To(BeEmpty())
+
+			rule.Spec.Actions.Label = &readinessv1alpha1.LabelAction{Key: "not a label", Value: "not a value"}
+			allErrs := webhook.validateSpec(rule.Spec)
+
+			var foundErrors []string
+			for _, err := range allErrs {
+				foundErrors = append(foundErrors, err.Field)
+			}
+			Expect(foundErrors).To(ConsistOf(
+				"spec.actions.label.key",
+				"spec.actions.label.value",
+			))
+
+			// An empty actions block does not replace the taints
+			rule.Spec.Actions = &readinessv1alpha1.GateActions{}
+			allErrs = webhook.validateSpec(rule.Spec)
+			Expect(allErrs).To(HaveLen(1))
+			Expect(allErrs[0].Field).To(Equal("spec.taints"))
+		})
+
 		It("should validate condition requirements", func() {
 			rule := &readinessv1alpha1.NodeReadinessGateRule{
 				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
@@ -84,7 +146,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 							// Missing type and requiredStatus
 						},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "test-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -110,7 +172,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "test-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -124,6 +186,311 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 			Expect(allErrs[0].Type).To(Equal(field.ErrorTypeInvalid))
 		})
 
+		It("should validate condition groups", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					ConditionGroups: []readinessv1alpha1.ConditionGroup{
+						{
+							Name:     "cni",
+							Operator: readinessv1alpha1.ConditionGroupOperatorAny,
+							Conditions: []readinessv1alpha1.ConditionRequirement{
+								{Type: "CalicoReady", RequiredStatus: corev1.ConditionTrue},
+								{Type: "CiliumReady", RequiredStatus: corev1.ConditionTrue},
+							},
+						},
+						{
+							Name:         "gpu",
+							Operator:     readinessv1alpha1.ConditionGroupOperatorAtLeast,
+							MinSatisfied: 2,
+							Groups: []readinessv1alpha1.ConditionSubGroup{
+								{
+									Name:     "gpu0",
+									Operator: readinessv1alpha1.ConditionGroupOperatorAll,
+									Conditions: []readinessv1alpha1.ConditionRequirement{
+										{Type: "GPU0Healthy", RequiredStatus: corev1.ConditionTrue},
+									},
+								},
+								{
+									Name:     "gpu1",
+									Operator: readinessv1alpha1.ConditionGroupOperatorAll,
+									Conditions: []readinessv1alpha1.ConditionRequirement{
+										{Type: "GPU1Healthy", RequiredStatus: corev1.ConditionTrue},
+									},
+								},
+							},
+						},
+					},
+					Taint: &readinessv1alpha1.TaintSpec{
+						Key:    "test-key",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			// Groups alone satisfy the condition requirement
+			Expect(webhook.validateSpec(rule.Spec)).To(BeEmpty())
+
+			// Invalid groups
+			rule.Spec.ConditionGroups[0].Operator = "Some"
+			rule.Spec.ConditionGroups[1].MinSatisfied = 3
+			rule.Spec.ConditionGroups[1].Groups[1].Name = "gpu0"
+			rule.Spec.ConditionGroups = append(rule.Spec.ConditionGroups, readinessv1alpha1.ConditionGroup{
+				Name:     "cni",
+				Operator: readinessv1alpha1.ConditionGroupOperatorAll,
+			})
+
+			allErrs := webhook.validateSpec(rule.Spec)
+			var foundErrors []string
+			for _, err := range allErrs {
+				foundErrors = append(foundErrors, err.Field)
+			}
+
+			Expect(foundErrors).To(ConsistOf(
+				"spec.conditionGroups[0].operator",
+				"spec.conditionGroups[1].minSatisfied",
+				"spec.conditionGroups[1].groups[1].name",
+				"spec.conditionGroups[2].name",
+				"spec.conditionGroups[2].conditions",
+			))
+		})
+
+		It("should compile expressions at admission", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Expressions: []readinessv1alpha1.ExpressionRequirement{
+						{Name: "has-gpu", Expression: "node.status.allocatable['nvidia.com/gpu'] > 0"},
+						{Name: "syntax-error", Expression: "node.metadata.labels["},
+						{Name: "not-bool", Expression: "size(node.metadata.labels)"},
+						{Name: "has-gpu", Expression: "true"},
+						{
+							Name:       "too-expensive",
+							Expression: "node.status.conditions.all(a, node.status.conditions.all(b, a.status == b.status))",
+						},
+					},
+					Taint: &readinessv1alpha1.TaintSpec{
+						Key:    "test-key",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			allErrs := webhook.validateSpec(rule.Spec)
+			var foundErrors []string
+			for _, err := range allErrs {
+				foundErrors = append(foundErrors, err.Field)
+			}
+
+			Expect(foundErrors).To(ConsistOf(
+				"spec.expressions[1].expression",
+				"spec.expressions[2].expression",
+				"spec.expressions[3].name",
+				"spec.expressions[4].expression",
+			))
+		})
+
+		It("should validate missing condition policies and maxAge", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "A", RequiredStatus: corev1.ConditionTrue, MissingPolicy: readinessv1alpha1.MissingPolicyTreatAsSatisfied},
+						{
+							Type: "B", RequiredStatus: corev1.ConditionTrue,
+							MissingPolicy:  readinessv1alpha1.MissingPolicyTreatAsSatisfiedAfter,
+							MissingTimeout: &metav1.Duration{Duration: time.Minute},
+						},
+						{Type: "C", RequiredStatus: corev1.ConditionTrue, MissingPolicy: readinessv1alpha1.MissingPolicyTreatAsSatisfiedAfter},
+						{Type: "D", RequiredStatus: corev1.ConditionTrue, MissingTimeout: &metav1.Duration{Duration: time.Minute}},
+						{Type: "E", RequiredStatus: corev1.ConditionTrue, MissingPolicy: "Ignore"},
+						{Type: "F", RequiredStatus: corev1.ConditionTrue, MaxAge: &metav1.Duration{Duration: time.Minute}},
+						{Type: "G", RequiredStatus: corev1.ConditionTrue, MaxAge: &metav1.Duration{}},
+					},
+					Taint: &readinessv1alpha1.TaintSpec{
+						Key:    "test-key",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			allErrs := webhook.validateSpec(rule.Spec)
+			var foundErrors []string
+			for _, err := range allErrs {
+				foundErrors = append(foundErrors, err.Field)
+			}
+
+			Expect(foundErrors).To(ConsistOf(
+				"spec.conditions[2].missingTimeout",
+				"spec.conditions[3].missingTimeout",
+				"spec.conditions[4].missingPolicy",
+				"spec.conditions[6].maxAge",
+			))
+		})
+
+		It("should reject a negative grace period", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taint: &readinessv1alpha1.TaintSpec{
+						Key:    "test-key",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+					GracePeriod:     &metav1.Duration{Duration: -time.Second},
+				},
+			}
+
+			allErrs := webhook.validateSpec(rule.Spec)
+			Expect(allErrs).To(HaveLen(1))
+			Expect(allErrs[0].Field).To(Equal("spec.gracePeriod"))
+			Expect(allErrs[0].Type).To(Equal(field.ErrorTypeInvalid))
+		})
+
+		It("should reject a negative stableFor", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taint: &readinessv1alpha1.TaintSpec{
+						Key:    "test-key",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+					StableFor:       &metav1.Duration{Duration: -time.Second},
+				},
+			}
+
+			allErrs := webhook.validateSpec(rule.Spec)
+			Expect(allErrs).To(HaveLen(1))
+			Expect(allErrs[0].Field).To(Equal("spec.stableFor"))
+			Expect(allErrs[0].Type).To(Equal(field.ErrorTypeInvalid))
+		})
+
+		It("should validate maxUnavailable", func() {
+			spec := readinessv1alpha1.NodeReadinessGateRuleSpec{
+				Conditions: []readinessv1alpha1.ConditionRequirement{
+					{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+				},
+				Taint: &readinessv1alpha1.TaintSpec{
+					Key:    "test-key",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+				EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+			}
+
+			for _, valid := range []intstr.IntOrString{intstr.FromInt32(0), intstr.FromInt32(5), intstr.FromString("25%")} {
+				spec.MaxUnavailable = &valid
+				Expect(webhook.validateSpec(spec)).To(BeEmpty(), "maxUnavailable %s", valid.String())
+			}
+
+			for _, invalid := range []intstr.IntOrString{intstr.FromInt32(-1), intstr.FromString("150%"), intstr.FromString("ten")} {
+				spec.MaxUnavailable = &invalid
+				allErrs := webhook.validateSpec(spec)
+				Expect(allErrs).To(HaveLen(1), "maxUnavailable %s", invalid.String())
+				Expect(allErrs[0].Field).To(Equal("spec.maxUnavailable"))
+				Expect(allErrs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			}
+		})
+
+		It("should validate the rollout strategy", func() {
+			spec := readinessv1alpha1.NodeReadinessGateRuleSpec{
+				Conditions: []readinessv1alpha1.ConditionRequirement{
+					{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+				},
+				Taint: &readinessv1alpha1.TaintSpec{
+					Key:    "test-key",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+				EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				Rollout: &readinessv1alpha1.RolloutStrategy{
+					BatchSize: intstr.FromString("10%"),
+					Pause:     metav1.Duration{Duration: time.Minute},
+					OrderBy:   "topology.kubernetes.io/zone",
+				},
+			}
+			Expect(webhook.validateSpec(spec)).To(BeEmpty())
+
+			spec.Rollout = &readinessv1alpha1.RolloutStrategy{
+				BatchSize: intstr.FromInt32(0),
+				Pause:     metav1.Duration{Duration: -time.Minute},
+				OrderBy:   "invalid key",
+			}
+			allErrs := webhook.validateSpec(spec)
+			Expect(allErrs).To(HaveLen(3))
+			Expect(allErrs[0].Field).To(Equal("spec.rollout.batchSize"))
+			Expect(allErrs[1].Field).To(Equal("spec.rollout.pause"))
+			Expect(allErrs[2].Field).To(Equal("spec.rollout.orderBy"))
+		})
+
+		It("should validate the canary strategy", func() {
+			validPercentage, invalidPercentage := int32(10), int32(120)
+			spec := readinessv1alpha1.NodeReadinessGateRuleSpec{
+				Conditions: []readinessv1alpha1.ConditionRequirement{
+					{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+				},
+				Taint: &readinessv1alpha1.TaintSpec{
+					Key:    "test-key",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+				EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				Canary: &readinessv1alpha1.CanaryStrategy{
+					Percentage:   &validPercentage,
+					PromoteAfter: &metav1.Duration{Duration: time.Hour},
+				},
+			}
+			Expect(webhook.validateSpec(spec)).To(BeEmpty())
+
+			spec.Canary = &readinessv1alpha1.CanaryStrategy{}
+			allErrs := webhook.validateSpec(spec)
+			Expect(allErrs).To(HaveLen(1))
+			Expect(allErrs[0].Field).To(Equal("spec.canary"))
+			Expect(allErrs[0].Type).To(Equal(field.ErrorTypeRequired))
+
+			spec.Canary = &readinessv1alpha1.CanaryStrategy{
+				Percentage:   &invalidPercentage,
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				PromoteAfter: &metav1.Duration{},
+			}
+			allErrs = webhook.validateSpec(spec)
+			Expect(allErrs).To(HaveLen(3))
+			Expect(allErrs[0].Field).To(Equal("spec.canary.selector"))
+			Expect(allErrs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(allErrs[1].Field).To(Equal("spec.canary.percentage"))
+			Expect(allErrs[2].Field).To(Equal("spec.canary.promoteAfter"))
+		})
+
+		It("should only allow rearmOn on bootstrap-only rules", func() {
+			rule := &readinessv1alpha1.NodeReadinessGateRule{
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taint: &readinessv1alpha1.TaintSpec{
+						Key:    "test-key",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+					RearmOn: &readinessv1alpha1.RearmPolicy{
+						BootID: true,
+						Labels: []string{"invalid key"},
+					},
+				},
+			}
+
+			allErrs := webhook.validateSpec(rule.Spec)
+			Expect(allErrs).To(HaveLen(2))
+			Expect(allErrs[0].Field).To(Equal("spec.rearmOn"))
+			Expect(allErrs[1].Field).To(Equal("spec.rearmOn.labels[0]"))
+
+			rule.Spec.EnforcementMode = readinessv1alpha1.EnforcementModeBootstrapOnly
+			rule.Spec.RearmOn.Labels = []string{"example.com/image"}
+			Expect(webhook.validateSpec(rule.Spec)).To(BeEmpty())
+		})
+
 		It("should pass validation for valid spec", func() {
 			rule := &readinessv1alpha1.NodeReadinessGateRule{
 				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
@@ -131,7 +498,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 						{Type: "NetworkReady", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "test-key",
 						Effect: corev1.TaintEffectNoSchedule,
 						Value:  "pending",
@@ -159,7 +526,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "conflict-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -181,7 +548,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "NetworkReady", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "conflict-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -196,6 +563,48 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 			Expect(allErrs[0].Detail).To(ContainSubstring("conflicts with existing rule"))
 		})
 
+		It("should check each taint of a rule for conflicts", func() {
+			existingRule := &readinessv1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing-rule"},
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taints: []readinessv1alpha1.TaintSpec{
+						{Key: "gate", Effect: corev1.TaintEffectNoSchedule},
+						{Key: "gate", Effect: corev1.TaintEffectNoExecute},
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(existingRule).
+				Build()
+			webhook = NewNodeReadinessGateRuleWebhook(fakeClient)
+
+			// Only the second taint collides with the existing rule
+			newRule := &readinessv1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "new-rule"},
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "NetworkReady", RequiredStatus: corev1.ConditionTrue},
+					},
+					Taints: []readinessv1alpha1.TaintSpec{
+						{Key: "gate", Effect: corev1.TaintEffectPreferNoSchedule},
+						{Key: "gate", Effect: corev1.TaintEffectNoExecute},
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			allErrs := webhook.validateTaintConflicts(ctx, newRule, false)
+			Expect(allErrs).To(HaveLen(1))
+			Expect(allErrs[0].Field).To(Equal("spec.taints[1].key"))
+			Expect(allErrs[0].Detail).To(ContainSubstring("existing-rule"))
+		})
+
 		It("should allow same taint key with different effects", func() {
 			// Create existing rule
 			existingRule := &readinessv1alpha1.NodeReadinessGateRule{
@@ -204,7 +613,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "same-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -226,7 +635,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "NetworkReady", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "same-key",
 						Effect: corev1.TaintEffectNoExecute, // Different effect
 					},
@@ -246,7 +655,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "update-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -270,6 +679,56 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 			allErrs := webhook.validateTaintConflicts(ctx, updatedRule, true) // isUpdate = true
 			Expect(allErrs).To(BeEmpty())                                     // No conflicts - updating same rule
 		})
+
+		It("should detect conflicting label keys and condition types", func() {
+			existingRule := &readinessv1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing-rule"},
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
+					},
+					Actions: &readinessv1alpha1.GateActions{
+						Label:     &readinessv1alpha1.LabelAction{Key: "example.com/gated", Value: "true"},
+						Condition: &readinessv1alpha1.ConditionAction{},
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(existingRule).
+				Build()
+			webhook = NewNodeReadinessGateRuleWebhook(fakeClient)
+
+			// Same label key with another value, and the existing rule's default condition type
+			newRule := &readinessv1alpha1.NodeReadinessGateRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "new-rule"},
+				Spec: readinessv1alpha1.NodeReadinessGateRuleSpec{
+					Conditions: []readinessv1alpha1.ConditionRequirement{
+						{Type: "NetworkReady", RequiredStatus: corev1.ConditionTrue},
+					},
+					Actions: &readinessv1alpha1.GateActions{
+						Label:     &readinessv1alpha1.LabelAction{Key: "example.com/gated", Value: "false"},
+						Condition: &readinessv1alpha1.ConditionAction{Type: "ReadinessGates/existing-rule"},
+					},
+					EnforcementMode: readinessv1alpha1.EnforcementModeContinuous,
+				},
+			}
+
+			allErrs := webhook.validateActionConflicts(ctx, newRule, false)
+			var foundErrors []string
+			for _, err := range allErrs {
+				foundErrors = append(foundErrors, err.Field)
+				Expect(err.Detail).To(ContainSubstring("existing-rule"))
+			}
+			Expect(foundErrors).To(ConsistOf("spec.actions.label.key", "spec.actions.condition.type"))
+
+			// Default condition types differ between rules
+			newRule.Spec.Actions.Label = nil
+			newRule.Spec.Actions.Condition.Type = ""
+			Expect(webhook.validateActionConflicts(ctx, newRule, false)).To(BeEmpty())
+		})
 	})
 
 	Context("Node Selector Overlap Detection", func() {
@@ -335,7 +794,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "create-test-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -369,7 +828,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "update-test-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -416,7 +875,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "Ready", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "comprehensive-key",
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -438,7 +897,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "NetworkReady", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "different-key", // No conflict
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -456,7 +915,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 					Conditions: []readinessv1alpha1.ConditionRequirement{
 						{Type: "StorageReady", RequiredStatus: corev1.ConditionTrue},
 					},
-					Taint: readinessv1alpha1.TaintSpec{
+					Taint: &readinessv1alpha1.TaintSpec{
 						Key:    "comprehensive-key", // Conflicts with existing
 						Effect: corev1.TaintEffectNoSchedule,
 					},
@@ -477,7 +936,7 @@ var _ = Describe("NodeReadinessGateRule Validation Webhook", func() {
 			}
 
 			allErrs = webhook.validateNodeReadinessGateRule(ctx, invalidRule, false)
-			Expect(allErrs).To(HaveLen(4)) // Multiple validation failures
+			Expect(allErrs).To(HaveLen(3)) // Multiple validation failures
 		})
 	})
 })